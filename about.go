@@ -0,0 +1,127 @@
+package help
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"testing/fstest"
+)
+
+const (
+	// aboutPage is the page generated from `Options.AppInfo`, and
+	// aboutPageName its documentation file.
+	aboutPage     = "about"
+	aboutPageName = aboutPage + ".md"
+	// aboutAnchor is the anchor of the generated about page's heading.
+	aboutAnchor = "about"
+)
+
+// AppInfo describes the application for the about page generated from
+// `Options.AppInfo`.
+type AppInfo struct {
+	// Name is the application's name.
+	Name string
+	// Version is the application's version, such as "1.4.2".
+	Version string
+	// Build identifies the build, such as a commit hash or build date.
+	Build string
+}
+
+// aboutMarkdown returns the markdown of the about page for info.
+func aboutMarkdown(info AppInfo) []byte {
+	var b strings.Builder
+	b.WriteString("# About\n\n")
+	if info.Name != "" {
+		fmt.Fprintf(&b, "**%s**\n\n", info.Name)
+	}
+	if info.Version != "" {
+		fmt.Fprintf(&b, "- Version: %s\n", info.Version)
+	}
+	if info.Build != "" {
+		fmt.Fprintf(&b, "- Build: %s\n", info.Build)
+	}
+	return []byte(b.String())
+}
+
+// aboutFS adds the generated about page to the root of a documentation
+// filesystem that has no "about.md" of its own.
+type aboutFS struct {
+	fs.FS
+	page fstest.MapFS
+}
+
+// withAbout returns fsys with the about page for info added, or fsys itself
+// if info is empty or fsys is a remote source, which cannot be listed.
+func withAbout(fsys fs.FS, info AppInfo) fs.FS {
+	if info == (AppInfo{}) {
+		return fsys
+	}
+	if _, ok := fsys.(*httpFS); ok {
+		return fsys
+	}
+	return &aboutFS{FS: fsys, page: fstest.MapFS{aboutPageName: {Data: aboutMarkdown(info), Mode: 0o444}}}
+}
+
+// Open opens the named file, falling back to the about page.
+func (f *aboutFS) Open(name string) (fs.File, error) {
+	file, err := f.FS.Open(name)
+	if err != nil && name == aboutPageName {
+		return f.page.Open(name)
+	}
+	return file, err
+}
+
+// ReadDir lists the named directory, with the about page added to the root.
+func (f *aboutFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(f.FS, name)
+	if err != nil || name != "." || f.hasOwn() {
+		return entries, err
+	}
+	page, err := f.page.ReadDir(".")
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, page...)
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries, nil
+}
+
+// hasOwn reports whether the underlying filesystem has an about page of its
+// own, which is used instead of the generated one.
+func (f *aboutFS) hasOwn() bool {
+	_, err := fs.Stat(f.FS, aboutPageName)
+	return err == nil
+}
+
+// aboutTarget returns the navigation target of the generated about page,
+// and reports whether one is generated for the current documentation.
+func (s *Service) aboutTarget() (string, bool) {
+	about, ok := s.currentAssets().(*aboutFS)
+	if !ok || about.hasOwn() {
+		return "", false
+	}
+	return aboutPage + "#" + aboutAnchor, true
+}
+
+// serveAbout serves the generated about page as HTML, for a request to its
+// page in the current doc set and locale, and reports whether it did.
+func (s *Service) serveAbout(w http.ResponseWriter, name, dir string, css, js []string) bool {
+	if name != path.Join(dir, aboutPage) && name != path.Join(dir, aboutPage+".html") {
+		return false
+	}
+	if _, ok := s.aboutTarget(); !ok {
+		return false
+	}
+	body, err := s.RenderPage(aboutPageName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	doc := "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>About</title>\n</head>\n<body>\n<main>\n" + body + "</main>\n</body>\n</html>\n"
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(injectExtras([]byte(doc), css, js))
+	return true
+}
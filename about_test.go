@@ -0,0 +1,70 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var aboutInfo = AppInfo{Name: "Ledger", Version: "1.4.2", Build: "abc123"}
+
+func TestAppInfo(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, AppInfo: aboutInfo})
+
+	pages, err := s.ListPages()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"about.md", "index.md"}, pages)
+
+	page, err := s.RenderPage("about.md")
+	assert.NoError(t, err)
+	assert.Contains(t, page, `<h1 id="about">About</h1>`)
+	assert.Contains(t, page, "<strong>Ledger</strong>")
+	assert.Contains(t, page, "<li>Version: 1.4.2</li>")
+	assert.Contains(t, page, "<li>Build: abc123</li>")
+
+	toc, err := s.TableOfContents()
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, toc) {
+		assert.Equal(t, TOCEntry{Title: "About", Anchor: "about", Level: 1, Path: "about.md"}, toc[len(toc)-1])
+	}
+
+	assert.NoError(t, s.ShowAt("about"))
+	assert.Equal(t, "/about.html#about", mockDisplay.Options["URL"])
+}
+
+func TestAppInfo_Unset(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	pages, err := s.ListPages()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"index.md"}, pages)
+	assert.Error(t, s.ShowAt("about"))
+}
+
+func TestAppInfo_OwnPage(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{
+		Assets:  NewMemorySource(map[string]string{"index.md": "# Home\n\n## About\n", "about.md": "# Our Story\n"}),
+		AppInfo: aboutInfo,
+	})
+
+	data, err := s.ReadPage("about.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Our Story\n", string(data))
+	pages, err := s.ListPages()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"about.md", "index.md"}, pages)
+
+	assert.NoError(t, s.ShowAt("about"))
+	assert.Equal(t, "/#about", mockDisplay.Options["URL"])
+}
+
+func TestServe_AboutPage(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs, AppInfo: aboutInfo})
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	page := get(t, url+"about")
+	assert.Contains(t, page, "<title>About</title>")
+	assert.Contains(t, page, "<li>Version: 1.4.2</li>")
+}
@@ -0,0 +1,32 @@
+package help
+
+// accessibleCSS is the stylesheet added to every HTML page served by `Serve`
+// when `Options.Accessible` is set. It enlarges the base font and
+// strengthens the contrast of text, links, code, and focus outlines in both
+// the light ("default") and dark ("slate") color schemes of the mkdocs
+// material theme, so that it works with whichever scheme the page is served
+// in; see `colorScheme`.
+const accessibleCSS = `html { font-size: 125%; }
+body { line-height: 1.6; }
+:focus, :focus-visible { outline: 3px solid #ff8c00; outline-offset: 2px; }
+a { text-decoration: underline; }
+[data-md-color-scheme="default"] {
+  --md-default-fg-color: #000; --md-default-bg-color: #fff;
+  --md-typeset-color: #000; --md-typeset-a-color: #0000c8;
+  --md-code-fg-color: #000; --md-code-bg-color: #f0f0f0;
+}
+[data-md-color-scheme="slate"] {
+  --md-default-fg-color: #fff; --md-default-bg-color: #000;
+  --md-typeset-color: #fff; --md-typeset-a-color: #ffff5c;
+  --md-code-fg-color: #fff; --md-code-bg-color: #1a1a1a;
+}`
+
+// extraCSS returns the stylesheets to add to served HTML pages: those of
+// `Options.ExtraCSS`, followed by the accessible stylesheet if
+// `Options.Accessible` is set. It must be called with mu held.
+func (s *Service) extraCSS() []string {
+	if !s.opts.Accessible {
+		return s.opts.ExtraCSS
+	}
+	return append(append([]string(nil), s.opts.ExtraCSS...), accessibleCSS)
+}
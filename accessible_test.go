@@ -0,0 +1,40 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+func TestAccessible(t *testing.T) {
+	docs := NewMemorySource(map[string]string{
+		"index.html": "<html><head></head><body>Docs</body></html>",
+	})
+	s, _, mockDisplay := setupService(t, Options{
+		Assets:     docs,
+		Accessible: true,
+		Theme:      ThemeDark,
+		ExtraCSS:   []string{"/brand.css"},
+	})
+	assert.Equal(t, application.WindowStateMaximised, s.windowOptions("/").StartState)
+
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+	page := get(t, url)
+	assert.Contains(t, page, `<link rel="stylesheet" href="/brand.css"><style>html { font-size: 125%; }`)
+	assert.Contains(t, page, `[data-md-color-scheme="slate"]`)
+
+	assert.NoError(t, s.Show())
+	assert.Equal(t, true, mockDisplay.Options["Maximised"])
+	assert.Equal(t, url+"?theme=dark", mockDisplay.Options["URL"])
+}
+
+func TestAccessible_Off(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	assert.Equal(t, application.WindowStateNormal, s.windowOptions("/").StartState)
+	assert.NotContains(t, s.extraCSS(), accessibleCSS)
+	assert.NoError(t, s.Show())
+	assert.NotContains(t, mockDisplay.Options, "Maximised")
+}
@@ -0,0 +1,43 @@
+package help
+
+import (
+	"fmt"
+	"time"
+)
+
+// resetAutoClose starts the timer that closes the help window once
+// `Options.AutoCloseAfter` has passed, restarting it if it is running, so
+// that the window closes only after that long without navigation.
+func (s *Service) resetAutoClose() {
+	if s.opts.AutoCloseAfter <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closeTimer != nil {
+		s.closeTimer.Stop()
+	}
+	s.closeTimer = time.AfterFunc(s.opts.AutoCloseAfter, s.autoClose)
+}
+
+// autoClose closes the help window when the timer started by
+// `resetAutoClose` fires. Errors are logged; see `SetLogger`.
+func (s *Service) autoClose() {
+	s.mu.Lock()
+	s.closeTimer = nil
+	s.mu.Unlock()
+	s.logger().Info("help: closing idle help window")
+	if err := s.Close(); err != nil {
+		s.logger().Error(fmt.Sprintf("help: closing window: %v", err))
+	}
+}
+
+// stopAutoClose stops the timer started by `resetAutoClose`, if running.
+func (s *Service) stopAutoClose() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closeTimer != nil {
+		s.closeTimer.Stop()
+		s.closeTimer = nil
+	}
+}
@@ -0,0 +1,72 @@
+package help
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// closingDisplay records when the help window was closed. It is safe for
+// use from the auto-close timer.
+type closingDisplay struct {
+	MockDisplay
+	mu     sync.Mutex
+	closes []time.Time
+}
+
+func (d *closingDisplay) CloseWindow(name string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closes = append(d.closes, time.Now())
+	return nil
+}
+
+func (d *closingDisplay) closed() []time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]time.Time(nil), d.closes...)
+}
+
+func TestAutoCloseAfter(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs, AutoCloseAfter: 50 * time.Millisecond})
+	display := &closingDisplay{}
+	s.Init(mockCore, display)
+	closed := make(chan struct{}, 1)
+	s.OnClose(func() { closed <- struct{}{} })
+
+	assert.NoError(t, s.Show())
+	time.Sleep(25 * time.Millisecond)
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	navigated := time.Now()
+	time.Sleep(35 * time.Millisecond)
+	assert.Empty(t, display.closed(), "navigation restarts the timer")
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("help window not closed")
+	}
+	if times := display.closed(); assert.Len(t, times, 1) {
+		assert.GreaterOrEqual(t, times[0].Sub(navigated), 50*time.Millisecond)
+	}
+}
+
+func TestAutoCloseAfter_StoppedByClose(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs, AutoCloseAfter: 20 * time.Millisecond})
+	display := &closingDisplay{}
+	s.Init(mockCore, display)
+
+	assert.NoError(t, s.Show())
+	assert.NoError(t, s.Close())
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, display.closed(), 1)
+}
+
+func TestAutoCloseAfter_Zero(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	assert.NoError(t, s.Show())
+	assert.Nil(t, s.closeTimer)
+}
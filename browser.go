@@ -0,0 +1,74 @@
+package help
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Targets accepted by `Options.Target`.
+const (
+	// TargetWindow shows help in an application window, through the
+	// `Display` service or the `wails3` fallback.
+	TargetWindow = "window"
+	// TargetBrowser shows help in the system's default web browser.
+	TargetBrowser = "browser"
+)
+
+// checkTarget returns an error if target is not a supported target.
+func checkTarget(target string) error {
+	switch target {
+	case "", TargetWindow, TargetBrowser:
+		return nil
+	}
+	return fmt.Errorf("help: invalid target %q", target)
+}
+
+// inBrowser reports whether help is shown in the system browser.
+func (s *Service) inBrowser() bool {
+	return s.opts.Target == TargetBrowser
+}
+
+// openBrowser opens url in the system's default web browser. It is a
+// variable so that tests can replace it.
+var openBrowser = func(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("help: open browser: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// showInBrowser opens url, as built by `pageURL`, in the system browser.
+// Content served by the application itself has no address a browser can
+// reach, so the documentation server is started with `Serve` if it is not
+// running already, and url is rebased onto it. The `wails3` application
+// opens the browser when it is running.
+func (s *Service) showInBrowser(url string) error {
+	if !isRemoteSource(url) {
+		s.mu.Lock()
+		served := s.serveURL
+		s.mu.Unlock()
+		if served == "" {
+			var err error
+			if served, err = s.Serve(""); err != nil {
+				return err
+			}
+		}
+		url = served + strings.TrimPrefix(strings.TrimPrefix(url, s.opts.BasePath), "/")
+	}
+	if app := s.wailsApp(); app != nil && app.Browser != nil {
+		return app.Browser.OpenURL(url)
+	}
+	return openBrowser(url)
+}
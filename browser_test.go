@@ -0,0 +1,58 @@
+package help
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubBrowser replaces the system browser for the duration of the test and
+// returns the URLs it is asked to open.
+func stubBrowser(t *testing.T) *[]string {
+	var opened []string
+	original := openBrowser
+	openBrowser = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+	t.Cleanup(func() { openBrowser = original })
+	return &opened
+}
+
+func TestTargetBrowser(t *testing.T) {
+	opened := stubBrowser(t)
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, Target: TargetBrowser})
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	assert.NoError(t, s.Show())
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.Error(t, s.ShowAt("missing-anchor"))
+	assert.False(t, mockDisplay.OpenCalled)
+
+	if assert.Len(t, *opened, 2) {
+		assert.True(t, strings.HasPrefix((*opened)[0], "http://127.0.0.1:"))
+		assert.True(t, strings.HasSuffix((*opened)[0], "/"))
+		assert.Equal(t, (*opened)[0]+"#good-anchor", (*opened)[1])
+	}
+	assert.Contains(t, get(t, (*opened)[0]+"index.md"), "## Good Anchor")
+
+	assert.NoError(t, s.Hide())
+	assert.NoError(t, s.Close())
+	assert.Empty(t, s.serveURL)
+}
+
+func TestTargetBrowser_RemoteSource(t *testing.T) {
+	opened := stubBrowser(t)
+	server := newDocsServer(t)
+	s, _, _ := setupService(t, Options{Source: server.URL + "/docs", Target: TargetBrowser})
+
+	assert.NoError(t, s.ShowAt("anything"))
+	assert.Equal(t, []string{server.URL + "/docs/#anything"}, *opened)
+	assert.Empty(t, s.serveURL)
+}
+
+func TestNew_InvalidTarget(t *testing.T) {
+	_, err := New(Options{Target: "tab"})
+	assert.Error(t, err)
+}
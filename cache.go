@@ -0,0 +1,101 @@
+package help
+
+import (
+	"bytes"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+// maxCachedPages is the number of files a pageCache holds at most.
+const maxCachedPages = 256
+
+// pageCache holds the files an httpFS has fetched, by URL, so that they are
+// fetched again only once `Options.CacheTTL` has passed. It holds at most
+// `maxCachedPages` files. A nil pageCache caches nothing.
+type pageCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cachedPage
+}
+
+// cachedPage is a file held by a pageCache, with the validators the server
+// sent for it.
+type cachedPage struct {
+	data         []byte
+	modTime      time.Time
+	etag         string
+	lastModified string
+	fetched      time.Time
+}
+
+// newPageCache returns a cache that keeps files for ttl, or nil if ttl is
+// not positive.
+func newPageCache(ttl time.Duration) *pageCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &pageCache{ttl: ttl, entries: make(map[string]cachedPage)}
+}
+
+// get returns the cached file for url, and whether it is still fresh.
+func (c *pageCache) get(url string) (page cachedPage, ok, fresh bool) {
+	if c == nil {
+		return cachedPage{}, false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	page, ok = c.entries[url]
+	return page, ok, ok && time.Since(page.fetched) < c.ttl
+}
+
+// put caches page for url, as fetched now. If the cache is full, stale
+// files are dropped to make room, or else the file fetched longest ago.
+func (c *pageCache) put(url string, page cachedPage) {
+	if c == nil {
+		return
+	}
+	page.fetched = time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[url]; !ok && len(c.entries) >= maxCachedPages {
+		c.evict(page.fetched)
+	}
+	c.entries[url] = page
+}
+
+// evict drops the files that are stale at now, or the file fetched longest
+// ago if none are. It must be called with mu held.
+func (c *pageCache) evict(now time.Time) {
+	oldest := ""
+	for url, page := range c.entries {
+		if now.Sub(page.fetched) >= c.ttl {
+			delete(c.entries, url)
+		} else if oldest == "" || page.fetched.Before(c.entries[oldest].fetched) {
+			oldest = url
+		}
+	}
+	if len(c.entries) >= maxCachedPages {
+		delete(c.entries, oldest)
+	}
+}
+
+// revalidate adds the validators of page to req, so that the server can
+// answer that it has not changed.
+func (page cachedPage) revalidate(req *http.Request) {
+	if page.etag != "" {
+		req.Header.Set("If-None-Match", page.etag)
+	}
+	if page.lastModified != "" {
+		req.Header.Set("If-Modified-Since", page.lastModified)
+	}
+}
+
+// file returns the cached file as the named file of an httpFS.
+func (page cachedPage) file(name string) *httpFile {
+	return &httpFile{
+		Reader: bytes.NewReader(page.data),
+		info:   httpFileInfo{name: path.Base(name), size: int64(len(page.data)), modTime: page.modTime},
+	}
+}
@@ -0,0 +1,137 @@
+package help
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// etagServer serves one page with an ETag, answering a request carrying the
+// current ETag with 304 Not Modified, and counts the requests of each kind.
+type etagServer struct {
+	mu          sync.Mutex
+	body        string
+	version     int
+	fetches     int
+	notModified int
+}
+
+func (e *etagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	etag := fmt.Sprintf(`"v%d"`, e.version)
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		e.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	e.fetches++
+	_, _ = w.Write([]byte(e.body))
+}
+
+func (e *etagServer) counts() (fetches, notModified int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.fetches, e.notModified
+}
+
+func (e *etagServer) update(body string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.body = body
+	e.version++
+}
+
+func TestCacheTTL(t *testing.T) {
+	docs := &etagServer{body: "# One\n"}
+	server := httptest.NewServer(docs)
+	t.Cleanup(server.Close)
+	s, _, _ := setupService(t, Options{Source: server.URL + "/docs", CacheTTL: 50 * time.Millisecond})
+
+	for range 3 {
+		data, err := s.ReadPage("index.md")
+		assert.NoError(t, err)
+		assert.Equal(t, "# One\n", string(data))
+	}
+	fetches, notModified := docs.counts()
+	assert.Equal(t, 1, fetches)
+	assert.Equal(t, 0, notModified)
+
+	// Once stale, an unchanged page is revalidated, not fetched again.
+	time.Sleep(60 * time.Millisecond)
+	data, err := s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# One\n", string(data))
+	fetches, notModified = docs.counts()
+	assert.Equal(t, 1, fetches)
+	assert.Equal(t, 1, notModified)
+
+	// A changed page is picked up after the TTL.
+	docs.update("# Two\n")
+	data, err = s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# One\n", string(data))
+	time.Sleep(60 * time.Millisecond)
+	data, err = s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Two\n", string(data))
+}
+
+func TestCacheTTL_Zero(t *testing.T) {
+	docs := &etagServer{body: "# One\n"}
+	server := httptest.NewServer(docs)
+	t.Cleanup(server.Close)
+	s, _, _ := setupService(t, Options{Source: server.URL + "/docs"})
+
+	for range 2 {
+		_, err := s.ReadPage("index.md")
+		assert.NoError(t, err)
+	}
+	fetches, notModified := docs.counts()
+	assert.Equal(t, 2, fetches)
+	assert.Equal(t, 0, notModified)
+}
+
+func TestPageCache_Bounded(t *testing.T) {
+	c := newPageCache(time.Hour)
+	now := time.Now()
+	for i := range maxCachedPages {
+		c.put(fmt.Sprintf("https://docs.example.com/%d.md", i), cachedPage{})
+	}
+	for i := range maxCachedPages {
+		url := fmt.Sprintf("https://docs.example.com/%d.md", i)
+		page := c.entries[url]
+		page.fetched = now.Add(time.Duration(i-maxCachedPages) * time.Second)
+		c.entries[url] = page
+	}
+	c.put("https://docs.example.com/new.md", cachedPage{})
+	assert.Len(t, c.entries, maxCachedPages)
+	_, ok, _ := c.get("https://docs.example.com/0.md")
+	assert.False(t, ok, "the file fetched longest ago is dropped")
+	_, ok, _ = c.get("https://docs.example.com/1.md")
+	assert.True(t, ok)
+	_, ok, _ = c.get("https://docs.example.com/new.md")
+	assert.True(t, ok)
+
+	// Stale files are dropped first.
+	c = newPageCache(time.Hour)
+	for i := range maxCachedPages {
+		c.put(fmt.Sprintf("https://docs.example.com/%d.md", i), cachedPage{})
+	}
+	for url, page := range c.entries {
+		if url != "https://docs.example.com/0.md" {
+			page.fetched = page.fetched.Add(-2 * time.Hour)
+			c.entries[url] = page
+		}
+	}
+	c.put("https://docs.example.com/new.md", cachedPage{})
+	assert.Len(t, c.entries, 2)
+	_, ok, _ = c.get("https://docs.example.com/0.md")
+	assert.True(t, ok)
+}
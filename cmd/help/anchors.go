@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Snider/help"
+	"github.com/spf13/cobra"
+)
+
+// newAnchorsCmd returns the `anchors` command, which prints every anchor in
+// the documentation one per line. It is intended for CI checks that each
+// `ShowAt` call refers to an anchor that exists.
+func newAnchorsCmd() *cobra.Command {
+	var source string
+	cmd := &cobra.Command{
+		Use:   "anchors",
+		Short: "List every anchor that ShowAt can navigate to",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := help.New(help.Options{Source: source})
+			if err != nil {
+				return err
+			}
+			anchors, err := s.Anchors()
+			if err != nil {
+				return err
+			}
+			for _, anchor := range anchors {
+				fmt.Fprintln(cmd.OutOrStdout(), anchor)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&source, "source", "", "documentation directory or URL (defaults to the embedded docs)")
+	return cmd
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnchorsCmd(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "index.md"), []byte("# Welcome\n\n## Getting Started\n"), 0o644)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	root := newRootCmd()
+	root.SetOut(&out)
+	root.SetArgs([]string{"anchors", "--source", dir})
+	err = root.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "getting-started\nwelcome\n", out.String())
+}
+
+func TestAnchorsCmd_MissingSource(t *testing.T) {
+	root := newRootCmd()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"anchors", "--source", filepath.Join(t.TempDir(), "missing")})
+	err := root.Execute()
+	assert.Error(t, err)
+}
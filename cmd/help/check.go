@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Snider/help"
+	"github.com/spf13/cobra"
+)
+
+// anchorCall is a call to `ShowAt` or `ShowAtContext` with a string literal
+// anchor, found in Go source.
+type anchorCall struct {
+	pos    token.Position
+	anchor string
+}
+
+// newCheckCmd returns the `check` command, which scans Go source for calls
+// to `ShowAt` with a literal anchor and reports each one whose anchor does
+// not exist in the documentation. It fails if any are missing, so that it
+// can guard against drift between code and docs in CI.
+func newCheckCmd() *cobra.Command {
+	var source string
+	var code []string
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check that every ShowAt anchor in Go source exists",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := help.New(help.Options{Source: source})
+			if err != nil {
+				return err
+			}
+			if _, err := s.Anchors(); err != nil {
+				return err
+			}
+
+			var calls []anchorCall
+			for _, pattern := range code {
+				found, err := findAnchorCalls(pattern)
+				if err != nil {
+					return err
+				}
+				calls = append(calls, found...)
+			}
+
+			missing := 0
+			for _, call := range calls {
+				if !anchorExists(s, call.anchor) {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: anchor %q not found\n", call.pos.Filename, call.pos.Line, call.anchor)
+					missing++
+				}
+			}
+			if missing > 0 {
+				return fmt.Errorf("%d of %d anchors not found", missing, len(calls))
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "All %d anchors found\n", len(calls))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&source, "source", "", "documentation directory or URL (defaults to the embedded docs)")
+	cmd.Flags().StringSliceVar(&code, "code", []string{"./..."}, "Go files or directories to scan; a trailing /... includes subdirectories")
+	return cmd
+}
+
+// anchorExists reports whether `ShowAt` can navigate to anchor. It checks
+// the anchor as `LinkFor` does, against the anchors listed by `Anchors`, and
+// treats an empty anchor as missing, as ShowAt does.
+func anchorExists(s *help.Service, anchor string) bool {
+	if strings.Trim(anchor, "# ") == "" {
+		return false
+	}
+	_, err := s.LinkFor(anchor)
+	return err == nil
+}
+
+// findAnchorCalls parses the Go files matched by pattern and returns their
+// `ShowAt` calls with a literal anchor. The pattern is a Go file, a
+// directory, or a directory followed by "/..." to include its
+// subdirectories. As with the go tool, directories named "testdata" or
+// "vendor", or starting with "." or "_", are skipped.
+func findAnchorCalls(pattern string) ([]anchorCall, error) {
+	root, recursive := strings.CutSuffix(pattern, "/...")
+	if root == "" {
+		root = "."
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return parseAnchorCalls(root)
+	}
+
+	var calls []anchorCall
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p == root {
+				return nil
+			}
+			name := d.Name()
+			if !recursive || name == "testdata" || name == "vendor" ||
+				strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(p) != ".go" {
+			return nil
+		}
+		found, err := parseAnchorCalls(p)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, found...)
+		return nil
+	})
+	return calls, err
+}
+
+// parseAnchorCalls returns the `ShowAt` and `ShowAtContext` calls in the Go
+// file at p whose anchor is a string literal, in source order.
+func parseAnchorCalls(p string) ([]anchorCall, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, p, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var calls []anchorCall
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		arg := -1
+		switch sel.Sel.Name {
+		case "ShowAt":
+			arg = 0
+		case "ShowAtContext":
+			arg = 1
+		}
+		if arg < 0 || len(call.Args) <= arg {
+			return true
+		}
+		lit, ok := call.Args[arg].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		anchor, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+		calls = append(calls, anchorCall{pos: fset.Position(lit.Pos()), anchor: anchor})
+		return true
+	})
+	return calls, nil
+}
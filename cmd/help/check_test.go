@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCmd(t *testing.T) {
+	docs := t.TempDir()
+	err := os.WriteFile(filepath.Join(docs, "index.md"), []byte("# Welcome\n\n## Getting Started\n"), 0o644)
+	assert.NoError(t, err)
+
+	code := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(code, "ui"), 0o755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(code, "testdata"), 0o755))
+	writeGo := func(name, body string) {
+		err := os.WriteFile(filepath.Join(code, name), []byte("package app\n\n"+body), 0o644)
+		assert.NoError(t, err)
+	}
+	writeGo("app.go", "func a() {\n\thelpService.ShowAt(\"welcome\")\n\thelpService.ShowAt(anchor)\n}\n")
+	writeGo("ui/menu.go", "func b() {\n\thelpService.ShowAtContext(ctx, \"#getting-started\")\n\thelpService.ShowAt(\"billing\")\n}\n")
+	writeGo("testdata/skip.go", "func c() { helpService.ShowAt(\"skipped\") }\n")
+
+	var out bytes.Buffer
+	root := newRootCmd()
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"check", "--code", code + "/...", "--source", docs})
+	err = root.Execute()
+	assert.EqualError(t, err, "1 of 3 anchors not found")
+	assert.Equal(t, filepath.Join(code, "ui", "menu.go")+":5: anchor \"billing\" not found\n", out.String())
+
+	out.Reset()
+	root = newRootCmd()
+	root.SetOut(&out)
+	root.SetArgs([]string{"check", "--code", code, "--source", docs})
+	err = root.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "All 1 anchors found\n", out.String())
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Snider/help"
+	"github.com/spf13/cobra"
+)
+
+// newCoverageCmd returns the `coverage` command, which checks that every
+// anchor listed in a file of required topics exists in the documentation.
+// It reports each missing anchor and fails if any are missing, so that a
+// checklist of must-document topics can gate a release in CI.
+func newCoverageCmd() *cobra.Command {
+	var source, required string
+	cmd := &cobra.Command{
+		Use:   "coverage",
+		Short: "Check that every required anchor is documented",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			anchors, err := readAnchorList(required)
+			if err != nil {
+				return err
+			}
+			s, err := help.New(help.Options{Source: source})
+			if err != nil {
+				return err
+			}
+			missing, err := s.CoverageReport(anchors)
+			if err != nil {
+				return err
+			}
+			for _, anchor := range missing {
+				fmt.Fprintf(cmd.OutOrStdout(), "anchor %q not documented\n", anchor)
+			}
+			if len(missing) > 0 {
+				return fmt.Errorf("%d of %d required anchors not documented", len(missing), len(anchors))
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "All %d required anchors documented\n", len(anchors))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&source, "source", "", "documentation directory or URL (defaults to the embedded docs)")
+	cmd.Flags().StringVar(&required, "required", "", "file listing the required anchors, one per line")
+	_ = cmd.MarkFlagRequired("required")
+	return cmd
+}
+
+// readAnchorList reads the file at path as a list of anchors, one per line.
+// Surrounding whitespace is trimmed and blank lines are skipped.
+func readAnchorList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var anchors []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if anchor := strings.TrimSpace(scanner.Text()); anchor != "" {
+			anchors = append(anchors, anchor)
+		}
+	}
+	return anchors, scanner.Err()
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverageCmd(t *testing.T) {
+	dir := t.TempDir()
+	docs := filepath.Join(dir, "docs")
+	assert.NoError(t, os.Mkdir(docs, 0o755))
+	err := os.WriteFile(filepath.Join(docs, "index.md"), []byte("# Welcome\n\n## Billing Cycle\n"), 0o644)
+	assert.NoError(t, err)
+	required := filepath.Join(dir, "anchors.txt")
+	assert.NoError(t, os.WriteFile(required, []byte("welcome\n\n  billing-cycle\n"), 0o644))
+
+	var out bytes.Buffer
+	root := newRootCmd()
+	root.SetOut(&out)
+	root.SetArgs([]string{"coverage", "--required", required, "--source", docs})
+	assert.NoError(t, root.Execute())
+	assert.Equal(t, "All 2 required anchors documented\n", out.String())
+
+	assert.NoError(t, os.WriteFile(required, []byte("welcome\nrefunds\n"), 0o644))
+	out.Reset()
+	root = newRootCmd()
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"coverage", "--required", required, "--source", docs})
+	assert.EqualError(t, root.Execute(), "1 of 2 required anchors not documented")
+	assert.Equal(t, "anchor \"refunds\" not documented\n", out.String())
+}
@@ -0,0 +1,41 @@
+// Command help is a command-line companion to the help package. It works with
+// the same documentation sources as the `help.Service`, without launching a
+// desktop application.
+//
+// Usage:
+//
+//	help anchors [--source path]
+//	help check [--code pattern] [--source path]
+//	help coverage --required file [--source path]
+//	help manifest [--source path]
+//	help serve [--source path] [--addr host:port] [--watch]
+//	help stats --file path [--top n]
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// newRootCmd returns the root `help` command with all of its subcommands.
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:          "help",
+		Short:        "Work with help documentation from the command line",
+		SilenceUsage: true,
+	}
+	root.AddCommand(newAnchorsCmd())
+	root.AddCommand(newCheckCmd())
+	root.AddCommand(newCoverageCmd())
+	root.AddCommand(newManifestCmd())
+	root.AddCommand(newServeCmd())
+	root.AddCommand(newStatsCmd())
+	return root
+}
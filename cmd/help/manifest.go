@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/Snider/help"
+	"github.com/spf13/cobra"
+)
+
+// newManifestCmd returns the `manifest` command, which prints the structure
+// of the documentation as JSON: its pages, the anchors of each, and the
+// table of contents. The output is sorted, so that it can be kept in version
+// control and fed to external navigation or search tooling.
+func newManifestCmd() *cobra.Command {
+	var source string
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Print the pages, anchors, and table of contents as JSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := help.New(help.Options{Source: source})
+			if err != nil {
+				return err
+			}
+			data, err := s.ExportManifest()
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&source, "source", "", "documentation directory or URL (defaults to the embedded docs)")
+	return cmd
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Snider/help"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestCmd(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "index.md"), []byte("# Welcome\n\n## Getting Started\n"), 0o644)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	root := newRootCmd()
+	root.SetOut(&out)
+	root.SetArgs([]string{"manifest", "--source", dir})
+	assert.NoError(t, root.Execute())
+
+	var manifest help.Manifest
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &manifest))
+	assert.Equal(t, []help.ManifestPage{
+		{Path: "index.md", Anchors: []string{"getting-started", "welcome"}},
+	}, manifest.Pages)
+	assert.Len(t, manifest.TOC, 1)
+}
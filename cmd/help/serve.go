@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/Snider/help"
+	"github.com/spf13/cobra"
+)
+
+// newServeCmd returns the `serve` command, which serves the documentation
+// over HTTP for previewing while it is being written. It blocks until it is
+// interrupted, then closes the server.
+func newServeCmd() *cobra.Command {
+	var source, addr string
+	var watch bool
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the documentation over HTTP for preview",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if watch && !isLocalSource(source) {
+				return errors.New("--watch requires a local --source directory")
+			}
+			// The command has no windows to show, only the server.
+			s, err := help.New(help.Options{Source: source, Headless: true})
+			if err != nil {
+				return err
+			}
+			url, err := s.Serve(addr)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Serving help at %s\n", url)
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if watch {
+				fmt.Fprintf(out, "Watching %s for changes\n", source)
+				err = s.Watch(ctx)
+			} else {
+				<-ctx.Done()
+			}
+			return errors.Join(err, s.Close())
+		},
+	}
+	cmd.Flags().StringVar(&source, "source", "", "documentation directory or URL (defaults to the embedded docs)")
+	cmd.Flags().StringVar(&addr, "addr", "", "address to listen on (defaults to a random local port)")
+	cmd.Flags().BoolVar(&watch, "watch", false, "reload the documentation when files in --source change")
+	return cmd
+}
+
+// isLocalSource reports whether source names a local documentation directory
+// rather than the embedded docs, a zip archive, or a remote URL.
+func isLocalSource(source string) bool {
+	if source == "" || source == "mkdocs" || strings.EqualFold(filepath.Ext(source), ".zip") {
+		return false
+	}
+	return !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://")
+}
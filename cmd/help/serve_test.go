@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeCmd(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	root := newRootCmd()
+	root.SetOut(&out)
+	root.SetArgs([]string{"serve", "--source", t.TempDir()})
+	err := root.ExecuteContext(ctx)
+	assert.NoError(t, err)
+	url, ok := strings.CutPrefix(strings.TrimSpace(out.String()), "Serving help at ")
+	if assert.True(t, ok, out.String()) {
+		assert.Contains(t, url, "http://127.0.0.1:")
+		// The server is closed when the command returns.
+		_, err = http.Get(url)
+		assert.Error(t, err)
+	}
+}
+
+func TestServeCmd_WatchNeedsLocalSource(t *testing.T) {
+	root := newRootCmd()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"serve", "--watch"})
+	err := root.Execute()
+	assert.Error(t, err)
+
+	root = newRootCmd()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"serve", "--watch", "--source", "docs.zip"})
+	assert.EqualError(t, root.Execute(), "--watch requires a local --source directory")
+}
+
+func TestServeCmd_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	dir := t.TempDir()
+
+	var out bytes.Buffer
+	root := newRootCmd()
+	root.SetOut(&out)
+	root.SetArgs([]string{"serve", "--source", dir, "--watch"})
+	assert.NoError(t, root.ExecuteContext(ctx))
+	assert.Contains(t, out.String(), "Watching "+dir+" for changes\n")
+}
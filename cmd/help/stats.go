@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Snider/help"
+	"github.com/spf13/cobra"
+)
+
+// anchorCount is the number of times an anchor was opened.
+type anchorCount struct {
+	anchor string
+	count  int
+}
+
+// newStatsCmd returns the `stats` command, which summarizes a metrics file
+// written by the service with `Options.MetricsFile`: it prints the anchors
+// opened most often, with their counts, most opened first.
+func newStatsCmd() *cobra.Command {
+	var file string
+	var top int
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Print the most opened anchors from a metrics file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			events, err := help.ReadMetrics(f)
+			if err != nil {
+				return err
+			}
+
+			counts := topAnchors(events)
+			if top > 0 && len(counts) > top {
+				counts = counts[:top]
+			}
+			for _, c := range counts {
+				fmt.Fprintf(cmd.OutOrStdout(), "%6d  %s\n", c.count, c.anchor)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d events\n", len(events))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "metrics file written with Options.MetricsFile")
+	cmd.Flags().IntVar(&top, "top", 10, "number of anchors to print; 0 prints all")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// topAnchors counts how often each anchor was opened in events, and returns
+// the counts, highest first and then by anchor. The documentation root is
+// counted as "/".
+func topAnchors(events []help.MetricEvent) []anchorCount {
+	byAnchor := make(map[string]int)
+	for _, event := range events {
+		anchor := event.Anchor
+		if anchor == "" {
+			anchor = "/"
+		}
+		byAnchor[anchor]++
+	}
+	counts := make([]anchorCount, 0, len(byAnchor))
+	for anchor, count := range byAnchor {
+		counts = append(counts, anchorCount{anchor, count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].count != counts[j].count {
+			return counts[i].count > counts[j].count
+		}
+		return counts[i].anchor < counts[j].anchor
+	})
+	return counts
+}
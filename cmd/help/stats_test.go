@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsCmd(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "events.json")
+	events := `{"time":"2026-01-02T10:00:00Z","action":"show_at","anchor":"billing"}
+{"time":"2026-01-02T10:01:00Z","action":"show","anchor":""}
+{"time":"2026-01-02T10:02:00Z","action":"show_at","anchor":"refunds"}
+
+{"time":"2026-01-02T10:03:00Z","action":"back","anchor":"billing"}
+`
+	assert.NoError(t, os.WriteFile(file, []byte(events), 0o644))
+
+	var out bytes.Buffer
+	root := newRootCmd()
+	root.SetOut(&out)
+	root.SetArgs([]string{"stats", "--file", file, "--top", "2"})
+	assert.NoError(t, root.Execute())
+	assert.Equal(t, "     2  billing\n     1  /\n4 events\n", out.String())
+}
+
+func TestStatsCmd_InvalidFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "events.json")
+	assert.NoError(t, os.WriteFile(file, []byte("not json\n"), 0o644))
+
+	root := newRootCmd()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"stats", "--file", file})
+	assert.ErrorContains(t, root.Execute(), "metrics line 1")
+}
@@ -0,0 +1,162 @@
+package help
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// precompressed lists the encodings of pre-compressed sibling files that
+// `Serve` looks for, in order of preference, with their file extensions.
+var precompressed = []struct {
+	encoding string
+	ext      string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// serveCompressed serves a request for fsys with compression negotiated from
+// the Accept-Encoding header. If the client accepts it and the file has a
+// ".br" or ".gz" sibling, such as "index.html.gz", the sibling is served
+// with the matching Content-Encoding. Otherwise text content is gzipped on
+// the fly by next, which serves fsys uncompressed.
+func serveCompressed(w http.ResponseWriter, r *http.Request, fsys fs.FS, next http.Handler) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	accept := r.Header.Get("Accept-Encoding")
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" || strings.HasSuffix(r.URL.Path, "/") {
+		name = path.Join(name, "index.html")
+	}
+	// Injected HTML differs from its pre-compressed siblings, so it is only
+	// compressed on the fly.
+	_, injected := fsys.(*injectFS)
+	if info, err := fs.Stat(fsys, name); err == nil && !info.IsDir() && !(injected && isHTML(name)) {
+		for _, pc := range precompressed {
+			if acceptsEncoding(accept, pc.encoding) && serveSibling(w, r, fsys, name, pc.ext, pc.encoding) {
+				return
+			}
+		}
+	}
+
+	if r.Method != http.MethodGet || !acceptsEncoding(accept, "gzip") {
+		next.ServeHTTP(w, r)
+		return
+	}
+	// Ranges of the uncompressed file do not apply to the gzipped response.
+	r.Header.Del("Range")
+	gw := &gzipResponseWriter{ResponseWriter: w}
+	defer gw.close()
+	next.ServeHTTP(gw, r)
+}
+
+// serveSibling serves the file name+ext of fsys in place of name, with the
+// given Content-Encoding, and reports whether it did. The sibling is not
+// served if it does not exist, or if the content type of name cannot be
+// told from its extension.
+func serveSibling(w http.ResponseWriter, r *http.Request, fsys fs.FS, name, ext, encoding string) bool {
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		return false
+	}
+	info, err := fs.Stat(fsys, name+ext)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	data, err := fs.ReadFile(fsys, name+ext)
+	if err != nil {
+		return false
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Encoding", encoding)
+	http.ServeContent(w, r, name, info.ModTime(), bytes.NewReader(data))
+	return true
+}
+
+// acceptsEncoding reports whether an Accept-Encoding header value accepts
+// encoding, either by name or with "*", and not with a quality of zero.
+func acceptsEncoding(header, encoding string) bool {
+	for _, part := range strings.Split(header, ",") {
+		token, params, _ := strings.Cut(part, ";")
+		token = strings.TrimSpace(token)
+		if !strings.EqualFold(token, encoding) && token != "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isCompressible reports whether content of the given type benefits from
+// gzip compression.
+func isCompressible(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+xml"),
+		strings.HasSuffix(mediaType, "+json"):
+		return true
+	}
+	switch mediaType {
+	case "application/javascript", "application/json", "application/xml":
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter gzips a successful response with compressible content,
+// and passes any other response through unchanged.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	h := w.Header()
+	if code == http.StatusOK && h.Get("Content-Encoding") == "" && isCompressible(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// close flushes the gzip stream, if the response is being compressed.
+func (w *gzipResponseWriter) close() {
+	if w.gz != nil {
+		w.gz.Close()
+	}
+}
@@ -0,0 +1,116 @@
+package help
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipped(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(data))
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func gunzip(t *testing.T, data []byte) string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	out, err := io.ReadAll(gz)
+	assert.NoError(t, err)
+	return string(out)
+}
+
+func serveRequest(s *Service, target, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	s.serveHTTP(rec, req)
+	return rec
+}
+
+func TestServe_Precompressed(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.html":    {Data: []byte("<h1>Plain</h1>")},
+		"index.html.gz": {Data: gzipped(t, "<h1>Gzipped</h1>")},
+		"index.html.br": {Data: []byte("brotli")},
+	}
+	s, err := New(Options{Assets: docs})
+	assert.NoError(t, err)
+
+	rec := serveRequest(s, "/", "gzip")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Header().Values("Vary"), "Accept-Encoding")
+	assert.Equal(t, "<h1>Gzipped</h1>", gunzip(t, rec.Body.Bytes()))
+
+	rec = serveRequest(s, "/index.html", "gzip, br")
+	assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "brotli", rec.Body.String())
+
+	rec = serveRequest(s, "/", "")
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "<h1>Plain</h1>", rec.Body.String())
+}
+
+func TestServe_GzipOnTheFly(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.md": {Data: []byte("# Title\n\nSome text.\n")},
+		"logo.png": {Data: []byte("\x89PNG\r\n\x1a\nimage data")},
+	}
+	s, err := New(Options{Assets: docs, ExtraCSS: []string{"/brand.css"}})
+	assert.NoError(t, err)
+
+	rec := serveRequest(s, "/index.md", "gzip;q=1.0")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Empty(t, rec.Header().Get("Content-Length"))
+	assert.Equal(t, "# Title\n\nSome text.\n", gunzip(t, rec.Body.Bytes()))
+
+	rec = serveRequest(s, "/logo.png", "gzip")
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "\x89PNG\r\n\x1a\nimage data", rec.Body.String())
+
+	rec = serveRequest(s, "/missing.md", "gzip")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+}
+
+func TestServe_InjectedHTMLSkipsPrecompressed(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.html":    {Data: []byte("<html><head></head><body>Docs</body></html>")},
+		"index.html.gz": {Data: gzipped(t, "stale")},
+	}
+	s, err := New(Options{Assets: docs, ExtraCSS: []string{"/brand.css"}})
+	assert.NoError(t, err)
+
+	rec := serveRequest(s, "/", "gzip")
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	body := gunzip(t, rec.Body.Bytes())
+	assert.Contains(t, body, "/brand.css")
+	assert.Contains(t, body, "Docs")
+}
+
+func TestAcceptsEncoding(t *testing.T) {
+	assert.True(t, acceptsEncoding("gzip", "gzip"))
+	assert.True(t, acceptsEncoding("deflate, GZIP;q=0.5", "gzip"))
+	assert.True(t, acceptsEncoding("*", "br"))
+	assert.False(t, acceptsEncoding("gzip;q=0", "gzip"))
+	assert.False(t, acceptsEncoding("identity", "gzip"))
+	assert.False(t, acceptsEncoding("", "gzip"))
+}
@@ -0,0 +1,58 @@
+package help
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowAt_Concurrent(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+
+	var wg sync.WaitGroup
+	anchors := []string{"good-anchor", "test-anchor", "any-anchor"}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(anchor string) {
+			defer wg.Done()
+			assert.NoError(t, s.ShowAt(anchor))
+		}(anchors[i%len(anchors)])
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.Show())
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, windows.windows(), 1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.Len(t, s.windows, 1)
+	assert.NotNil(t, s.window)
+	assert.Len(t, s.history, 50)
+}
+
+func TestShowAt_ConcurrentDisplay(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	display := &countingDisplay{}
+	s.Init(&MockCore{}, display)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.ShowAt("good-anchor"))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, display.opened(), 50)
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+}
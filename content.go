@@ -0,0 +1,461 @@
+package help
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// headingPattern matches an ATX markdown heading, capturing its level
+	// markers and its text.
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	// setextPattern matches the underline of a setext markdown heading,
+	// `===` for the first level or `---` for the second.
+	setextPattern = regexp.MustCompile(`^ {0,3}(=+|-+)\s*$`)
+	// blockPattern matches the start of a markdown block that cannot be
+	// the text of a setext heading: a list item, a quote, or indented code.
+	blockPattern = regexp.MustCompile(`^(\s*([-*+]|\d+[.)])(\s|$)|\s*>| {4}|\t)`)
+	// headingIDPattern matches an explicit attribute-list ID such as
+	// `{#custom-id}` at the end of a heading.
+	headingIDPattern = regexp.MustCompile(`\s*\{\s*#([\w-]+)[^}]*\}\s*$`)
+	// linkPattern matches an inline markdown link or image.
+	linkPattern = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	// htmlIDPattern matches an `id` attribute on an HTML element.
+	htmlIDPattern = regexp.MustCompile(`\sid\s*=\s*["']([^"']+)["']`)
+	// htmlHeadingPattern matches an HTML heading with an `id` attribute,
+	// capturing its level, its ID, and its inner HTML.
+	htmlHeadingPattern = regexp.MustCompile(`(?is)<h([1-6])\b[^>]*\sid\s*=\s*["']([^"']+)["'][^>]*>(.*?)</h[1-6]\s*>`)
+	// htmlSkipPattern matches elements whose content is not document text.
+	htmlSkipPattern = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)\s*>`)
+	// htmlTagPattern matches any HTML tag or comment.
+	htmlTagPattern = regexp.MustCompile(`(?s)<!--.*?-->|<[^>]*>`)
+)
+
+// heading is a single heading extracted from a documentation file.
+type heading struct {
+	level  int
+	title  string
+	anchor string
+}
+
+// Anchors walks the documentation assets and returns every anchor ID that
+// can be navigated to with `ShowAt`. Anchors are taken from the headings of
+// markdown files, using the same slugs that mkdocs generates, and from the
+// `id` attributes of HTML files. The returned list is sorted and contains no
+// duplicates.
+//
+// Example:
+//
+//	anchors, err := helpService.Anchors()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, anchor := range anchors {
+//		fmt.Println(anchor)
+//	}
+func (s *Service) Anchors() ([]string, error) {
+	index, err := s.contentIndex(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return append(make([]string, 0, len(index.anchors)), index.anchors...), nil
+}
+
+// checkAnchor returns an error if anchor does not exist in the documentation.
+// An anchor may carry a page prefix (`page#anchor`), in which case the page
+// must exist and the anchor must be defined in it; see `findPage`. A page that
+// would resolve outside the documentation root is rejected with an error
+// wrapping `ErrInvalidPath`, and one hidden by `Options.PageFilter` with an
+// error wrapping `ErrNotFound`. Anchors on a remote source cannot be
+// enumerated and are not checked.
+func (s *Service) checkAnchor(anchor string) error {
+	if anchor == "" {
+		return nil
+	}
+	page, fragment, ok := splitTarget(anchor)
+	if page = strings.Trim(page, "/"); page != "" {
+		if _, err := cleanPath(page); err != nil {
+			return err
+		}
+		if s.pageHidden(page) {
+			return fmt.Errorf("help: page %q not found: %w", page, ErrNotFound)
+		}
+	}
+	fsys := s.currentAssets()
+	if _, ok := fsys.(*httpFS); ok {
+		return nil
+	}
+
+	if !ok {
+		anchors, err := s.Anchors()
+		if err != nil {
+			return err
+		}
+		i := sort.SearchStrings(anchors, fragment)
+		if i < len(anchors) && anchors[i] == fragment {
+			return nil
+		}
+		return fmt.Errorf("help: anchor %q not found", anchor)
+	}
+
+	file, ok := findPage(fsys, page)
+	if !ok {
+		return fmt.Errorf("help: page %q not found: %w", page, ErrNotFound)
+	}
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return err
+	}
+	for _, id := range fileAnchors(file, data) {
+		if id == fragment {
+			return nil
+		}
+	}
+	return fmt.Errorf("help: anchor %q not found", anchor)
+}
+
+// splitTarget splits a navigation target of the form `page#anchor` into its
+// page and anchor, and reports whether it had a page. A target without a `#`
+// is a bare anchor.
+func splitTarget(target string) (page, anchor string, ok bool) {
+	i := strings.Index(target, "#")
+	if i < 0 {
+		return "", target, false
+	}
+	return strings.TrimPrefix(target[:i], "/"), target[i+1:], true
+}
+
+// findPage returns the documentation file in fsys that page refers to. The
+// page may name the file itself, or be written as the site URL that mkdocs
+// builds from it: "install.html" or "install" for "install.md", and
+// "guide" or "guide/" for "guide/index.md" or "guide/index.html".
+func findPage(fsys fs.FS, page string) (string, bool) {
+	page = strings.Trim(page, "/")
+	stem := strings.TrimSuffix(strings.TrimSuffix(page, ".html"), ".htm")
+	candidates := []string{
+		page,
+		stem + ".md",
+		stem + ".html",
+		path.Join(page, "index.md"),
+		path.Join(page, "index.html"),
+	}
+	for _, candidate := range candidates {
+		if !isDocFile(candidate) {
+			continue
+		}
+		if info, err := fs.Stat(fsys, candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// sitePath returns the path, relative to the documentation root, at which
+// the help window shows the documentation file p: the directory of an index
+// page, as "guide/" for "guide/index.md", the ".html" page of another
+// markdown file, as "install.html" for "install.md", which `Serve` renders,
+// and any other file as it is.
+func sitePath(p string) string {
+	dir, base := path.Split(p)
+	switch {
+	case slices.Contains(indexPageNames, base):
+		return dir
+	case isMarkdown(p):
+		return strings.TrimSuffix(p, path.Ext(p)) + ".html"
+	}
+	return p
+}
+
+// anchorPage returns the documentation file that defines anchor, preferring
+// the root index page, and reports whether there is one.
+func (s *Service) anchorPage(anchor string) (string, bool) {
+	index, err := s.contentIndex(context.Background())
+	if err != nil {
+		return "", false
+	}
+	p, ok := index.anchorPages[anchor]
+	return p, ok
+}
+
+// currentAssets returns the documentation filesystem in use, within the
+// current locale, with the about page generated from `Options.AppInfo`.
+func (s *Service) currentAssets() fs.FS {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return withAbout(withFilter(localize(s.assets, s.contentDir()), s.opts.PageFilter), s.opts.AppInfo)
+}
+
+// rootAssets returns the documentation filesystem in use, including every
+// locale.
+func (s *Service) rootAssets() fs.FS {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.assets
+}
+
+// walkDocs calls fn with the path and contents of every markdown and HTML
+// file in fsys, in lexical path order, following symbolic links as
+// `walkDocPaths` does and calling warn for those it skips.
+func walkDocs(fsys fs.FS, warn func(msg string), fn func(p string, data []byte) error) error {
+	return walkDocPaths(fsys, warn, func(p string) error {
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		return fn(p, data)
+	})
+}
+
+// isDocFile reports whether p is a markdown or HTML documentation file.
+func isDocFile(p string) bool {
+	return isMarkdown(p) || isHTML(p)
+}
+
+// isMarkdown reports whether p is a markdown file.
+func isMarkdown(p string) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
+
+// isHTML reports whether p is an HTML file.
+func isHTML(p string) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".html", ".htm":
+		return true
+	}
+	return false
+}
+
+// fileAnchors returns the anchor IDs defined in a single documentation file.
+func fileAnchors(p string, data []byte) []string {
+	if isHTML(p) {
+		var ids []string
+		for _, m := range htmlIDPattern.FindAllSubmatch(data, -1) {
+			ids = append(ids, string(m[1]))
+		}
+		return ids
+	}
+	var ids []string
+	for _, h := range markdownHeadings(data) {
+		ids = append(ids, h.anchor)
+	}
+	return ids
+}
+
+// section is a heading together with the plain text that follows it, up to
+// the next heading. The preamble before the first heading is a section with
+// a zero level and no anchor.
+type section struct {
+	heading
+	text string
+}
+
+// markdownHeadings extracts the ATX and setext headings of a markdown
+// document in document order, skipping fenced code blocks. Each heading's
+// anchor is its explicit `{#id}` if present, or its slug otherwise; repeated
+// slugs within the document are suffixed with `_1`, `_2`, and so on, as
+// mkdocs does.
+func markdownHeadings(data []byte) []heading {
+	var headings []heading
+	for _, sec := range markdownSections(data) {
+		if sec.level > 0 {
+			headings = append(headings, sec.heading)
+		}
+	}
+	return headings
+}
+
+// markdownSections splits a markdown document into sections at its headings,
+// as found by `markdownHeadings`. Front matter is skipped.
+func markdownSections(data []byte) []section {
+	_, data = splitFrontMatter(data)
+	var sections []section
+	current := section{}
+	var body strings.Builder
+	flush := func() {
+		current.text = strings.Join(strings.Fields(body.String()), " ")
+		if current.level > 0 || current.text != "" {
+			sections = append(sections, current)
+		}
+		body.Reset()
+	}
+
+	// para holds the lines of the paragraph being read, which become a
+	// setext heading if a `===` or `---` underline follows them.
+	var para []string
+	endPara := func() {
+		for _, line := range para {
+			body.WriteString(plainText(line) + "\n")
+		}
+		para = nil
+	}
+	used := make(map[string]int)
+	addHeading := func(level int, title string) {
+		anchor := ""
+		if id := headingIDPattern.FindStringSubmatch(title); id != nil {
+			anchor = id[1]
+			title = strings.TrimSpace(headingIDPattern.ReplaceAllString(title, ""))
+		} else {
+			anchor = slugify(title)
+			if n, ok := used[anchor]; ok {
+				used[anchor] = n + 1
+				anchor = fmt.Sprintf("%s_%d", anchor, n+1)
+			}
+		}
+		used[anchor] = 0
+
+		flush()
+		current = section{heading: heading{
+			level:  level,
+			title:  plainText(title),
+			anchor: anchor,
+		}}
+	}
+
+	fence := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			} else {
+				body.WriteString(line + "\n")
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			endPara()
+			fence = trimmed[:3]
+			continue
+		}
+
+		if m := setextPattern.FindStringSubmatch(line); m != nil {
+			if len(para) == 0 {
+				// A thematic break.
+				continue
+			}
+			for i := range para {
+				para[i] = strings.TrimSpace(para[i])
+			}
+			level := 2
+			if m[1][0] == '=' {
+				level = 1
+			}
+			title := strings.Join(para, " ")
+			para = nil
+			addHeading(level, title)
+			continue
+		}
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			endPara()
+			addHeading(len(m[1]), m[2])
+			continue
+		}
+		switch {
+		case trimmed == "":
+			endPara()
+		case len(para) == 0 && blockPattern.MatchString(line):
+			body.WriteString(plainText(line) + "\n")
+		default:
+			para = append(para, line)
+		}
+	}
+	endPara()
+	flush()
+	return sections
+}
+
+// htmlSections splits an HTML document into sections at its headings that
+// carry an `id` attribute, with the markup stripped from the text.
+func htmlSections(data []byte) []section {
+	doc := htmlSkipPattern.ReplaceAllString(string(data), " ")
+	var sections []section
+	current := section{}
+	flush := func(fragment string) {
+		current.text = htmlText(fragment)
+		if current.level > 0 || current.text != "" {
+			sections = append(sections, current)
+		}
+	}
+
+	last := 0
+	for _, m := range htmlHeadingPattern.FindAllStringSubmatchIndex(doc, -1) {
+		flush(doc[last:m[0]])
+		current = section{heading: heading{
+			level:  int(doc[m[2]] - '0'),
+			anchor: doc[m[4]:m[5]],
+			title:  htmlText(doc[m[6]:m[7]]),
+		}}
+		last = m[1]
+	}
+	flush(doc[last:])
+	return sections
+}
+
+// htmlText returns the text content of an HTML fragment with whitespace
+// collapsed.
+func htmlText(fragment string) string {
+	text := html.UnescapeString(htmlTagPattern.ReplaceAllString(fragment, " "))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// fileSections splits a documentation file into sections.
+func fileSections(p string, data []byte) []section {
+	if isHTML(p) {
+		return htmlSections(data)
+	}
+	return markdownSections(data)
+}
+
+// plainText strips inline markdown link and code syntax from a heading.
+func plainText(title string) string {
+	title = linkPattern.ReplaceAllString(title, "$1")
+	return strings.NewReplacer("`", "", "**", "", "__", "").Replace(title)
+}
+
+// slugify converts a heading title to an anchor ID in the same way as the
+// mkdocs table of contents: markup is removed, accented letters are folded
+// to ASCII and other non-ASCII characters dropped, punctuation is removed,
+// the text is lower-cased, and runs of whitespace and hyphens become a
+// single hyphen. Whitespace at either end is dropped, but hyphens are kept.
+func slugify(title string) string {
+	title = norm.NFKD.String(plainText(title))
+	var b strings.Builder
+	run, dash := false, false
+	for _, r := range title {
+		switch {
+		case r >= utf8.RuneSelf:
+		case r == '-' || unicode.IsSpace(r):
+			run = true
+			dash = dash || r == '-'
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			if run && (dash || b.Len() > 0) {
+				b.WriteByte('-')
+			}
+			run, dash = false, false
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	if dash {
+		b.WriteByte('-')
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package help
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnchors(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.md": {Data: []byte("# Welcome\n\n## Getting Started\n\n## Getting Started\n\n" +
+			"```sh\n# not a heading\n```\n\n### Custom {#my-id}\n")},
+		"guide/setup.html": {Data: []byte(`<h1 id="setup">Setup</h1><p id='install'>Install</p>`)},
+		"image.png":        {Data: []byte("# not markdown")},
+	}
+	s, err := New(Options{Assets: docs})
+	assert.NoError(t, err)
+
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"getting-started",
+		"getting-started_1",
+		"install",
+		"my-id",
+		"setup",
+		"welcome",
+	}, anchors)
+}
+
+func TestMarkdownHeadings_Setext(t *testing.T) {
+	doc := "Welcome\n=======\n\nSome text.\n\nGetting\nStarted\n---\n\n" +
+		"- a list\n\n---\n\n    indented code\n    ---\n\n## ATX {#atx}\n"
+	assert.Equal(t, []heading{
+		{level: 1, title: "Welcome", anchor: "welcome"},
+		{level: 2, title: "Getting Started", anchor: "getting-started"},
+		{level: 2, title: "ATX", anchor: "atx"},
+	}, markdownHeadings([]byte(doc)))
+
+	sections := markdownSections([]byte(doc))
+	assert.Equal(t, "Some text.", sections[0].text)
+}
+
+func TestAnchors_MissingSource(t *testing.T) {
+	s, err := New(Options{Source: "does-not-exist"})
+	assert.NoError(t, err)
+
+	_, err = s.Anchors()
+	assert.Error(t, err)
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Getting Started":             "getting-started",
+		"  Spaces   and - dashes  ":   "spaces-and-dashes",
+		"What's new in v1.2?":         "whats-new-in-v12",
+		"Using `ShowAt()`":            "using-showat",
+		"See [the guide](guide.md)":   "see-the-guide",
+		"snake_case stays":            "snake_case-stays",
+		"Ünïcödé Héadings":            "unicode-headings",
+		"Größe 日本語 ﬁle":               "groe-file",
+		"- Leading and trailing -":    "-leading-and-trailing-",
+		"Trailing !":                  "trailing",
+		"**Bold** and __underlined__": "bold-and-underlined",
+	}
+	for title, want := range tests {
+		assert.Equal(t, want, slugify(title), title)
+	}
+}
+
+func TestFindPage(t *testing.T) {
+	docs := fstest.MapFS{
+		"install.md":       {Data: []byte("# Install\n")},
+		"guide/index.html": {Data: []byte("<h1>Guide</h1>")},
+		"logo.png":         {Data: []byte("png")},
+	}
+	tests := map[string]string{
+		"install.md":       "install.md",
+		"install.html":     "install.md",
+		"install":          "install.md",
+		"/install":         "install.md",
+		"guide":            "guide/index.html",
+		"guide/":           "guide/index.html",
+		"guide/index.html": "guide/index.html",
+	}
+	for page, want := range tests {
+		got, ok := findPage(docs, page)
+		assert.True(t, ok, page)
+		assert.Equal(t, want, got, page)
+	}
+
+	for _, page := range []string{"missing", "logo.png", "guide/index.md"} {
+		_, ok := findPage(docs, page)
+		assert.False(t, ok, page)
+	}
+}
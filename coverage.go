@@ -0,0 +1,36 @@
+package help
+
+import "fmt"
+
+// CoverageReport checks that each of the required anchors exists in the
+// documentation, and returns those that do not, in the order given. It lets
+// a checklist of topics that must be documented gate a release. Anchors are
+// accepted as by `ShowAt`, including aliases from `Options.AnchorAliases`
+// and the `page#anchor` form; a blank anchor is reported missing. An error
+// is returned if the documentation cannot be read, or if it comes from a
+// remote source, whose anchors cannot be enumerated.
+//
+// Example:
+//
+//	missing, err := helpService.CoverageReport([]string{"billing-cycle", "refunds"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, anchor := range missing {
+//		fmt.Println("undocumented:", anchor)
+//	}
+func (s *Service) CoverageReport(requiredAnchors []string) (missing []string, err error) {
+	if _, ok := s.currentAssets().(*httpFS); ok {
+		return nil, fmt.Errorf("help: cannot report coverage of a remote source")
+	}
+	if _, err := s.Anchors(); err != nil {
+		return nil, err
+	}
+	for _, anchor := range requiredAnchors {
+		target := s.resolveAnchor(anchor)
+		if _, fragment, _ := splitTarget(target); fragment == "" || s.checkAnchor(target) != nil {
+			missing = append(missing, anchor)
+		}
+	}
+	return missing, nil
+}
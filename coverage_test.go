@@ -0,0 +1,32 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverageReport(t *testing.T) {
+	s, err := New(Options{
+		Assets:        testDocs,
+		AnchorAliases: map[string]string{"old-anchor": "good-anchor"},
+	})
+	assert.NoError(t, err)
+
+	missing, err := s.CoverageReport([]string{"good-anchor", "refunds", "#getting-started", "old-anchor", "index.md#any-anchor", "", "other.md#good-anchor"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"refunds", "", "other.md#good-anchor"}, missing)
+
+	missing, err = s.CoverageReport([]string{"test-anchor"})
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestCoverageReport_RemoteSource(t *testing.T) {
+	server := newDocsServer(t)
+	s, err := New(Options{Source: server.URL + "/docs"})
+	assert.NoError(t, err)
+
+	_, err = s.CoverageReport([]string{"remote"})
+	assert.ErrorContains(t, err, "remote source")
+}
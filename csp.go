@@ -0,0 +1,79 @@
+package help
+
+import (
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultContentSecurityPolicy returns the Content-Security-Policy that
+// `Serve` sends when `Options.ContentSecurityPolicy` is empty. It allows
+// resources from the documentation server itself only, plus the inline
+// scripts and styles that generated sites such as mkdocs rely on, images
+// and fonts given as data URLs, and the origins of any stylesheets in css
+// and scripts in js given by absolute URL, as in `Options.ExtraCSS` and
+// `Options.ExtraJS`.
+func defaultContentSecurityPolicy(css, js []string) string {
+	return "default-src 'self'" +
+		"; script-src 'self' 'unsafe-inline'" + extraOrigins(js) +
+		"; style-src 'self' 'unsafe-inline'" + extraOrigins(css) +
+		"; img-src 'self' data:; font-src 'self' data:" +
+		"; object-src 'none'; base-uri 'self'"
+}
+
+// extraOrigins returns the origins of the absolute URLs among extras, each
+// preceded by a space, for adding to a Content-Security-Policy directive.
+func extraOrigins(extras []string) string {
+	var origins strings.Builder
+	seen := map[string]bool{}
+	for _, extra := range extras {
+		if !strings.HasPrefix(extra, "http://") && !strings.HasPrefix(extra, "https://") && !strings.HasPrefix(extra, "//") {
+			continue
+		}
+		u, err := url.Parse(extra)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		origin := u.Host
+		if u.Scheme != "" {
+			origin = u.Scheme + "://" + u.Host
+		}
+		if !seen[origin] {
+			seen[origin] = true
+			origins.WriteString(" " + origin)
+		}
+	}
+	return origins.String()
+}
+
+// cspResponseWriter adds a Content-Security-Policy header to HTML responses,
+// as told by their Content-Type, and passes other responses through
+// unchanged.
+type cspResponseWriter struct {
+	http.ResponseWriter
+	policy      string
+	wroteHeader bool
+}
+
+func (w *cspResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if mediaType == "text/html" {
+		w.Header().Set("Content-Security-Policy", w.policy)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cspResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(p)
+}
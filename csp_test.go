@@ -0,0 +1,63 @@
+package help
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServe_ContentSecurityPolicy(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.html": {Data: []byte("<html><body>Docs</body></html>")},
+		"site.css":   {Data: []byte("body {}")},
+	}
+	s, _, _ := setupService(t, Options{
+		Assets:  docs,
+		ExtraJS: []string{"https://feedback.example.com/widget.js", "/local.js", "console.log('hi');"},
+	})
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	for _, page := range []string{url, url + "search.html"} {
+		resp, err := http.Get(page)
+		if assert.NoError(t, err) {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			policy := resp.Header.Get("Content-Security-Policy")
+			assert.Contains(t, policy, "default-src 'self'")
+			assert.Contains(t, policy, "script-src 'self' 'unsafe-inline' https://feedback.example.com;")
+		}
+	}
+
+	resp, err := http.Get(url + "site.css")
+	if assert.NoError(t, err) {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		assert.Empty(t, resp.Header.Get("Content-Security-Policy"))
+	}
+}
+
+func TestServe_CustomContentSecurityPolicy(t *testing.T) {
+	s, _, _ := setupService(t, Options{
+		Assets:                fstest.MapFS{"index.html": {Data: []byte("<html></html>")}},
+		ContentSecurityPolicy: "default-src 'self' https://videos.example.com",
+	})
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	assert.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if assert.NoError(t, err) {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		assert.Equal(t, "default-src 'self' https://videos.example.com", resp.Header.Get("Content-Security-Policy"))
+	}
+}
@@ -0,0 +1,57 @@
+package help
+
+import (
+	"context"
+	"time"
+)
+
+// debounceShowAt checks anchor and schedules the help window to be opened at
+// it, and placed at at if it is not nil, once `Options.NavigateDebounce` has
+// passed without another call. A call made while one is pending replaces its
+// anchor and position and restarts the wait, so a burst of calls opens the
+// window only once, at the last anchor.
+func (s *Service) debounceShowAt(anchor string, at *windowPoint) error {
+	if err := s.checkAnchor(anchor); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.navTimer != nil {
+		s.navTimer.Stop()
+	}
+	s.navAnchor, s.navAt = anchor, at
+	s.navTimer = time.AfterFunc(s.opts.NavigateDebounce, s.flushNavigate)
+	return nil
+}
+
+// flushNavigate opens the help window at the anchor left pending by
+// `debounceShowAt`, if any. As the original caller has already returned,
+// errors are logged; see `SetLogger`.
+func (s *Service) flushNavigate() {
+	s.mu.Lock()
+	anchor, at := s.navAnchor, s.navAt
+	s.navAnchor, s.navAt = "", nil
+	s.navTimer = nil
+	s.mu.Unlock()
+	if anchor == "" {
+		return
+	}
+
+	if err := s.openAtQuery(context.Background(), anchor, nil, at); err != nil {
+		s.logger().Error(err.Error())
+		return
+	}
+	s.pushHistory(anchor)
+	s.emitShow("show_at", anchor)
+}
+
+// cancelNavigate discards any navigation pending from `debounceShowAt`.
+func (s *Service) cancelNavigate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.navTimer != nil {
+		s.navTimer.Stop()
+		s.navTimer = nil
+	}
+	s.navAnchor, s.navAt = "", nil
+}
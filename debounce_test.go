@@ -0,0 +1,92 @@
+package help
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingDisplay records the URLs the help window was opened at, and the
+// options of the last opening. It is safe for use from the debounce timer.
+type countingDisplay struct {
+	MockDisplay
+	mu   sync.Mutex
+	urls []string
+	last map[string]any
+}
+
+func (d *countingDisplay) OpenWindow(name string, options map[string]any) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.urls = append(d.urls, options["URL"].(string))
+	d.last = options
+	return nil
+}
+
+func (d *countingDisplay) opened() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.urls...)
+}
+
+func TestShowAt_Debounce(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs, NavigateDebounce: 20 * time.Millisecond})
+	display := &countingDisplay{}
+	s.Init(mockCore, display)
+
+	for _, anchor := range []string{"test-anchor", "good-anchor", "any-anchor"} {
+		assert.NoError(t, s.ShowAt(anchor))
+	}
+	assert.Empty(t, display.opened())
+
+	assert.Eventually(t, func() bool {
+		return len(display.opened()) > 0
+	}, time.Second, 5*time.Millisecond)
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, []string{"/#any-anchor"}, display.opened())
+	assert.Equal(t, "any-anchor", s.CurrentAnchor())
+}
+
+func TestShowAtPosition_Debounce(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs, NavigateDebounce: 20 * time.Millisecond})
+	display := &countingDisplay{}
+	s.Init(mockCore, display)
+
+	assert.NoError(t, s.ShowAtPosition("test-anchor", 10, 20))
+	assert.NoError(t, s.ShowAtPosition("good-anchor", 30, 40))
+	assert.Empty(t, display.opened())
+
+	assert.Eventually(t, func() bool {
+		return len(display.opened()) > 0
+	}, time.Second, 5*time.Millisecond)
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, []string{"/#good-anchor"}, display.opened())
+	display.mu.Lock()
+	assert.Equal(t, 30, display.last["X"])
+	assert.Equal(t, 40, display.last["Y"])
+	display.mu.Unlock()
+}
+
+func TestShowAt_DebounceInvalidAnchor(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs, NavigateDebounce: 10 * time.Millisecond})
+	display := &countingDisplay{}
+	s.Init(mockCore, display)
+
+	assert.Error(t, s.ShowAt("missing"))
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, display.opened())
+}
+
+func TestShowAt_DebounceCancelledByClose(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs, NavigateDebounce: 20 * time.Millisecond})
+	display := &countingDisplay{}
+	s.Init(mockCore, display)
+
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.NoError(t, s.Close())
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, display.opened())
+	assert.Empty(t, s.CurrentAnchor())
+}
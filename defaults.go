@@ -0,0 +1,56 @@
+package help
+
+import (
+	"io/fs"
+	"sync"
+)
+
+var (
+	defaultAssetsMu sync.RWMutex
+	// defaultAssets replaces the embedded documentation when set; see
+	// `SetDefaultAssets`.
+	defaultAssets fs.FS
+)
+
+// SetDefaultAssets replaces the documentation embedded in this package with
+// fsys as the default source: the one `New` uses when neither
+// `Options.Source` nor `Options.Assets` is given, and that "mkdocs" names in
+// `Options.Sources`. It lets an application ship documentation of its own
+// as the zero-config default without forking the package. It affects
+// services created, or sources set with `SetSource`, after the call, so call
+// it early, such as from an `init` function. Passing nil restores the
+// embedded documentation.
+//
+// Example:
+//
+//	//go:embed all:docs
+//	var docs embed.FS
+//
+//	func init() {
+//		sub, _ := fs.Sub(docs, "docs")
+//		help.SetDefaultAssets(sub)
+//	}
+func SetDefaultAssets(fsys fs.FS) {
+	defaultAssetsMu.Lock()
+	defer defaultAssetsMu.Unlock()
+	defaultAssets = fsys
+}
+
+// defaultSource returns the default documentation filesystem: the one set
+// with `SetDefaultAssets`, or the embedded documentation.
+func defaultSource() (fs.FS, error) {
+	defaultAssetsMu.RLock()
+	defer defaultAssetsMu.RUnlock()
+	if defaultAssets != nil {
+		return defaultAssets, nil
+	}
+	return fs.Sub(helpStatic, "public")
+}
+
+// hasDefaultAssets reports whether `SetDefaultAssets` has replaced the
+// embedded documentation.
+func hasDefaultAssets() bool {
+	defaultAssetsMu.RLock()
+	defer defaultAssetsMu.RUnlock()
+	return defaultAssets != nil
+}
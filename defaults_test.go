@@ -0,0 +1,36 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDefaultAssets(t *testing.T) {
+	SetDefaultAssets(NewMemorySource(map[string]string{"index.md": "# Bundled\n"}))
+	t.Cleanup(func() { SetDefaultAssets(nil) })
+
+	for _, opts := range []Options{{}, {Source: "mkdocs"}, {Sources: []string{"mkdocs"}}} {
+		s, err := New(opts)
+		assert.NoError(t, err)
+		anchors, err := s.Anchors()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"bundled"}, anchors)
+	}
+
+	// An explicit source still wins.
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	assert.NoError(t, s.checkAnchor("good-anchor"))
+}
+
+func TestSetDefaultAssets_Empty(t *testing.T) {
+	SetDefaultAssets(NewMemorySource(nil))
+	t.Cleanup(func() { SetDefaultAssets(nil) })
+
+	s, err := New(Options{})
+	assert.NoError(t, err)
+	err = s.Validate()
+	assert.ErrorIs(t, err, ErrNoDocumentation)
+	assert.NotContains(t, err.Error(), "public/")
+}
@@ -0,0 +1,59 @@
+package help
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// SetDocSet switches to the documentation set in the directory key of the
+// source, such as "app-a" for `public/app-a/`, so that one service can serve
+// the docs of several sub-applications as the user moves between them.
+// Content lookups, anchors, and the help window URL are resolved within that
+// directory, and the locale is picked again within it. An empty key selects
+// the root of the source. An error is returned, and the current set kept, if
+// key is not a valid path or its directory does not exist. Open help
+// windows are reloaded to show the new set; see `reloadWindows`.
+//
+// Example:
+//
+//	if err := helpService.SetDocSet("billing"); err != nil {
+//		log.Fatal(err)
+//	}
+//	err := helpService.ShowAt("invoices")
+func (s *Service) SetDocSet(key string) error {
+	if err := checkDocSet(key); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if key != "" && !hasLocale(s.assets, key) {
+		s.mu.Unlock()
+		return fmt.Errorf("help: doc set %q not found", key)
+	}
+	previous := s.docURL()
+	s.opts.DocSet = key
+	s.locale = pickLocale(localize(s.assets, key), s.opts.Locale, s.opts.DefaultLocale)
+	s.mu.Unlock()
+	s.invalidateIndex()
+	s.warnLocale()
+	return s.reloadWindows(previous)
+}
+
+// checkDocSet returns an error if key is not a valid directory path.
+func checkDocSet(key string) error {
+	if key == "" || fs.ValidPath(key) && key != "." {
+		return nil
+	}
+	return fmt.Errorf("help: invalid doc set %q", key)
+}
+
+// contentDir returns the directory of the source that holds the
+// documentation in use: that of the doc set, and within it that of the
+// locale. It is "" for the root of the source. It must be called with mu
+// held.
+func (s *Service) contentDir() string {
+	if s.opts.DocSet == "" {
+		return s.locale
+	}
+	return path.Join(s.opts.DocSet, s.locale)
+}
@@ -0,0 +1,74 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var docSetDocs = NewMemorySource(map[string]string{
+	"app-a/index.md":    "# Alpha\n\n## Alpha Setup\n",
+	"app-b/index.md":    "# Beta\n",
+	"app-b/de/index.md": "# Beta Deutsch\n",
+})
+
+func TestDocSet(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: docSetDocs, DocSet: "app-a"})
+
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alpha", "alpha-setup"}, anchors)
+	assert.NoError(t, s.ShowAt("alpha-setup"))
+	assert.Equal(t, "/app-a/#alpha-setup", mockDisplay.Options["URL"])
+
+	assert.NoError(t, s.SetDocSet("app-b"))
+	assert.Error(t, s.ShowAt("alpha-setup"))
+	assert.NoError(t, s.Show())
+	assert.Equal(t, "/app-b/", mockDisplay.Options["URL"])
+	data, err := s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Beta\n", string(data))
+
+	assert.NoError(t, s.SetLocale("de"))
+	assert.NoError(t, s.ShowAt("beta-deutsch"))
+	assert.Equal(t, "/app-b/de/#beta-deutsch", mockDisplay.Options["URL"])
+
+	assert.NoError(t, s.SetDocSet(""))
+	assert.NoError(t, s.Show())
+	_, hasURL := mockDisplay.Options["URL"]
+	assert.False(t, hasURL)
+}
+
+func TestSetDocSet_ReloadsWindows(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: docSetDocs, DocSet: "app-a"})
+	assert.NoError(t, s.ShowAt("alpha-setup"))
+	mockDisplay.OpenCalled = false
+	assert.NoError(t, s.SetDocSet("app-b"))
+	assert.True(t, mockDisplay.OpenCalled)
+	assert.Equal(t, "/app-b/#alpha-setup", mockDisplay.Options["URL"])
+
+	s, err := New(Options{Assets: docSetDocs, DocSet: "app-a", WindowReuse: WindowReusePerAnchor})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+	assert.NoError(t, s.ShowAt("alpha"))
+	assert.NoError(t, s.ShowAt("alpha-setup"))
+	assert.NoError(t, s.SetDocSet("app-b"))
+	if assert.Len(t, windows.windows(), 2) {
+		first, _, _ := windows.windows()[0].state()
+		assert.Equal(t, "/app-b/#alpha", first)
+		second, _, _ := windows.windows()[1].state()
+		assert.Equal(t, "/app-b/#alpha-setup", second)
+	}
+}
+
+func TestSetDocSet_Invalid(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: docSetDocs, DocSet: "app-a"})
+
+	assert.Error(t, s.SetDocSet("app-c"))
+	assert.Error(t, s.SetDocSet("../app-a"))
+	assert.NoError(t, s.ShowAt("alpha"))
+
+	_, err := New(Options{Assets: docSetDocs, DocSet: "/app-a"})
+	assert.Error(t, err)
+}
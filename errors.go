@@ -0,0 +1,41 @@
+package help
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Errors returned when the help service is used before its dependencies are
+// available. Use `errors.Is` to test for them.
+var (
+	// ErrCoreNotInitialized is returned when the service needs the core
+	// runtime but `Init` has not been given one.
+	ErrCoreNotInitialized = errors.New("core runtime not initialized")
+	// ErrDisplayNotInitialized is returned when the help window cannot be
+	// managed because no `Display` service is available. The `wails3`
+	// fallback could not be used either, so the error also wraps
+	// `ErrWailsNotRunning`.
+	ErrDisplayNotInitialized = errors.New("display service not initialized")
+	// ErrWailsNotRunning is returned when the `wails3` fallback is needed
+	// but no `wails3` application is running.
+	ErrWailsNotRunning = errors.New("wails application not running")
+)
+
+// ErrInvalidPath is wrapped by the error returned when a documentation path
+// is malformed or would resolve outside the documentation root, such as
+// "../secrets" or "/etc/passwd".
+var ErrInvalidPath = errors.New("invalid path")
+
+// ErrNotFound is wrapped by the error returned when a documentation page
+// does not exist or is hidden by `Options.PageFilter`. It is
+// `fs.ErrNotExist`, so either can be tested for.
+var ErrNotFound = fs.ErrNotExist
+
+// ErrAnchorNotFound is wrapped by the error `SectionHTML` returns when no
+// heading in the documentation has the requested anchor.
+var ErrAnchorNotFound = errors.New("anchor not found")
+
+// errNoWindowing is returned when there is neither a `Display` service nor a
+// running `wails3` application to manage the help window with.
+var errNoWindowing = fmt.Errorf("%w: %w", ErrDisplayNotInitialized, ErrWailsNotRunning)
@@ -35,6 +35,26 @@ func (m *MockCore) App() help.App { return m.app }
 // MockDisplay is a mock implementation of the help.Display interface.
 type MockDisplay struct{}
 
+func (m *MockDisplay) OpenWindow(name string, options map[string]any) error {
+	fmt.Printf("OpenWindow called for %q with: %v\n", name, options)
+	return nil
+}
+
+func (m *MockDisplay) HideWindow(name string) error {
+	fmt.Printf("HideWindow called for %q\n", name)
+	return nil
+}
+
+func (m *MockDisplay) CloseWindow(name string) error {
+	fmt.Printf("CloseWindow called for %q\n", name)
+	return nil
+}
+
+func (m *MockDisplay) ReloadWindow(name string) error {
+	fmt.Printf("ReloadWindow called for %q\n", name)
+	return nil
+}
+
 // This example demonstrates how to use the ShowAt() function in the refactored help module.
 func main() {
 	// 1. Initialize the help service.
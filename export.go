@@ -0,0 +1,124 @@
+package help
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// ExportPDF writes the documentation section identified by anchor, with its
+// subsections, to a PDF file at outPath, for printing or handing to users.
+// An empty anchor exports the whole documentation set, page by page. As with
+// `ShowAt`, the anchor may name a page too, as in "install.html#setup". The
+// PDF holds the text of the documentation, laid out with its headings;
+// images and styling are not included.
+//
+// An error is returned before anything is written if the anchor does not
+// exist, or if outPath already exists and `Options.ExportOverwrite` is not
+// set.
+//
+// Example:
+//
+//	err := helpService.ExportPDF("getting-started", "getting-started.pdf")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (s *Service) ExportPDF(anchor, outPath string) error {
+	anchor = s.resolveAnchor(anchor)
+	if err := s.checkAnchor(anchor); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	overwrite := s.opts.ExportOverwrite
+	s.mu.Unlock()
+	if !overwrite {
+		if _, err := os.Stat(outPath); err == nil {
+			return fmt.Errorf("help: %s already exists", outPath)
+		}
+	}
+
+	sections, err := exportSections(s.currentAssets(), anchor, s.warnWalk)
+	if err != nil {
+		return err
+	}
+	if len(sections) == 0 {
+		return fmt.Errorf("help: nothing to export: %w", ErrNoDocumentation)
+	}
+
+	var w pdfWriter
+	for _, sec := range sections {
+		if sec.level > 0 {
+			w.heading(sec.level, sec.title)
+		}
+		if sec.text != "" {
+			w.paragraph(sec.text)
+		}
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(outPath, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("help: export %s: %w", outPath, err)
+	}
+	if _, err := f.Write(w.bytes()); err != nil {
+		f.Close()
+		return fmt.Errorf("help: export %s: %w", outPath, err)
+	}
+	return f.Close()
+}
+
+// exportSections returns the sections of fsys to export for anchor: every
+// section of every page if anchor is empty, or the section with that anchor
+// and its subsections otherwise. Symbolic links skipped while walking fsys
+// are reported to warn.
+func exportSections(fsys fs.FS, anchor string, warn func(msg string)) ([]section, error) {
+	if anchor == "" {
+		var sections []section
+		err := walkDocs(fsys, warn, func(p string, data []byte) error {
+			sections = append(sections, fileSections(p, data)...)
+			return nil
+		})
+		return sections, err
+	}
+
+	page, fragment, ok := splitTarget(anchor)
+	if ok {
+		file, found := findPage(fsys, page)
+		if !found {
+			return nil, fmt.Errorf("help: page %q not found: %w", page, ErrNotFound)
+		}
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		return subtree(fileSections(file, data), fragment), nil
+	}
+
+	var sections []section
+	err := walkDocs(fsys, warn, func(p string, data []byte) error {
+		if sections == nil {
+			sections = subtree(fileSections(p, data), fragment)
+		}
+		return nil
+	})
+	return sections, err
+}
+
+// subtree returns the section of sections with the given anchor, followed by
+// the sections nested under it, or nil if there is no such section.
+func subtree(sections []section, anchor string) []section {
+	for i, sec := range sections {
+		if sec.anchor != anchor || sec.level == 0 {
+			continue
+		}
+		end := i + 1
+		for end < len(sections) && sections[end].level > sec.level {
+			end++
+		}
+		return sections[i:end]
+	}
+	return nil
+}
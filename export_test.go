@@ -0,0 +1,95 @@
+package help
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// exportDocs has two pages so that exports of one section can be told apart
+// from exports of everything.
+var exportDocs = fstest.MapFS{
+	"index.md": {Data: []byte("# Welcome\n\nIntro text.\n\n## Setup\n\nRun (the) installer.\n\n" +
+		"### Options\n\nPick options.\n\n## Usage\n\nUse it.\n")},
+	"faq.html": {Data: []byte(`<h1 id="faq">FAQ</h1><p>Questions</p>`)},
+}
+
+func TestExportPDF(t *testing.T) {
+	s, err := New(Options{Assets: exportDocs})
+	assert.NoError(t, err)
+	out := filepath.Join(t.TempDir(), "docs.pdf")
+
+	assert.NoError(t, s.ExportPDF("", out))
+	data, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "%PDF-"))
+	assert.True(t, strings.HasSuffix(string(data), "%%EOF\n"))
+	for _, text := range []string{"(Welcome)", "(Setup)", "(Run \\(the\\) installer.)", "(Usage)", "(FAQ)"} {
+		assert.Contains(t, string(data), text)
+	}
+}
+
+func TestExportPDF_Anchor(t *testing.T) {
+	s, err := New(Options{Assets: exportDocs})
+	assert.NoError(t, err)
+	out := filepath.Join(t.TempDir(), "setup.pdf")
+
+	assert.NoError(t, s.ExportPDF("#setup", out))
+	data, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "(Setup)")
+	assert.Contains(t, string(data), "(Options)")
+	assert.NotContains(t, string(data), "(Usage)")
+	assert.NotContains(t, string(data), "(Welcome)")
+
+	out = filepath.Join(t.TempDir(), "faq.pdf")
+	assert.NoError(t, s.ExportPDF("faq.html#faq", out))
+	data, err = os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "(FAQ)")
+	assert.NotContains(t, string(data), "(Setup)")
+}
+
+func TestExportPDF_MissingAnchor(t *testing.T) {
+	s, err := New(Options{Assets: exportDocs})
+	assert.NoError(t, err)
+	out := filepath.Join(t.TempDir(), "missing.pdf")
+
+	assert.Error(t, s.ExportPDF("missing", out))
+	assert.Error(t, s.ExportPDF("faq.html#setup", out))
+	assert.NoFileExists(t, out)
+}
+
+func TestExportPDF_Overwrite(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "docs.pdf")
+	assert.NoError(t, os.WriteFile(out, []byte("keep"), 0o644))
+
+	s, err := New(Options{Assets: exportDocs})
+	assert.NoError(t, err)
+	assert.Error(t, s.ExportPDF("", out))
+	data, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, "keep", string(data))
+
+	s, err = New(Options{Assets: exportDocs, ExportOverwrite: true})
+	assert.NoError(t, err)
+	assert.NoError(t, s.ExportPDF("", out))
+	data, err = os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "%PDF-1.4")
+}
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, []string{"one two", "three", "abcdefg"}, wrapText("one  two\nthree abcdefg", 7))
+	assert.Equal(t, []string{"abcd", "efgh", "ij"}, wrapText("abcdefghij", 4))
+	assert.Empty(t, wrapText("  ", 4))
+}
+
+func TestPDFString(t *testing.T) {
+	assert.Equal(t, `a\(b\)\\c`, pdfString(`a(b)\c`))
+	assert.Equal(t, `caf\351 ?`, pdfString("café ✓"))
+}
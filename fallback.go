@@ -0,0 +1,98 @@
+package help
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// checkSourceTimeout bounds how long the first `Show` waits to find out
+// whether a remote source is reachable.
+const checkSourceTimeout = 5 * time.Second
+
+// CheckSource checks whether a remote documentation source is reachable,
+// and switches to `Options.FallbackAssets` if it is not, or back to the
+// remote source once it is reachable again. Each switch is logged, and open
+// help windows are pointed at the documentation now in use; see
+// `reloadWindows`. An error is
+// returned while the remote source is unreachable, even if the fallback is
+// in use. For any other source, CheckSource does nothing. `ServiceStartup`
+// calls it, through `Validate`, as does the first `Show` or `ShowAt` if the
+// service was not started; call it again, for example when the network
+// comes back, to return to the remote documentation.
+//
+// Example:
+//
+//	if err := helpService.CheckSource(ctx); err != nil {
+//		log.Println("help is offline:", err)
+//	}
+func (s *Service) CheckSource(ctx context.Context) error {
+	s.mu.Lock()
+	remote, _ := s.assets.(*httpFS)
+	offline := s.offline
+	fallback := s.opts.FallbackAssets
+	s.sourceChecked = true
+	s.mu.Unlock()
+
+	if offline != nil {
+		if err := offline.ping(ctx); err != nil {
+			return fmt.Errorf("help: source %q unreachable: %w", offline.base, err)
+		}
+		s.useAssets(offline, nil)
+		s.logger().Info(fmt.Sprintf("help: source %s reachable again, leaving fallback assets", offline.base))
+		return nil
+	}
+	if remote == nil {
+		return nil
+	}
+	err := remote.ping(ctx)
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("help: source %q unreachable: %w", remote.base, err)
+	if fallback != nil {
+		s.useAssets(fallback, remote)
+		s.logger().Error(fmt.Sprintf("%v; using fallback assets", err))
+	}
+	return err
+}
+
+// checkSourceOnce calls `CheckSource` if it has not been called yet, so
+// that help shown without `ServiceStartup` still falls back from an
+// unreachable remote source.
+func (s *Service) checkSourceOnce(ctx context.Context) {
+	s.mu.Lock()
+	checked := s.sourceChecked || s.opts.FallbackAssets == nil
+	s.mu.Unlock()
+	if checked {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, checkSourceTimeout)
+	defer cancel()
+	_ = s.CheckSource(ctx)
+}
+
+// isOffline reports whether `Options.FallbackAssets` are in use in place of
+// an unreachable remote source.
+func (s *Service) isOffline() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offline != nil
+}
+
+// useAssets switches the documentation in use to assets, keeping offline as
+// the remote source to return to, if any.
+func (s *Service) useAssets(assets fs.FS, offline *httpFS) {
+	s.mu.Lock()
+	previous := s.docURL()
+	s.assets = assets
+	s.offline = offline
+	s.locale = pickLocale(localize(assets, s.opts.DocSet), s.opts.Locale, s.opts.DefaultLocale)
+	s.mu.Unlock()
+	s.invalidateIndex()
+
+	if err := s.reloadWindows(previous); err != nil {
+		s.logger().Error(fmt.Sprintf("help: reload help windows: %v", err))
+	}
+}
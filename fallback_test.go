@@ -0,0 +1,93 @@
+package help
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFlakyDocsServer returns a docs server that fails every request while
+// down is set.
+func newFlakyDocsServer(t *testing.T, down *atomic.Bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("# Remote\n"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFallbackAssets_Startup(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+	server := newFlakyDocsServer(t, &down)
+	s, mockCore, mockDisplay := setupService(t, Options{
+		Source:         server.URL + "/docs/",
+		FallbackAssets: testDocs,
+	})
+
+	assert.NoError(t, s.ServiceStartup(context.Background()))
+	assert.True(t, mockCore.app.(*MockApp).logger.(*MockLogger).ErrorCalled)
+	info := s.SourceInfo()
+	assert.Equal(t, SourceHTTP, info.Kind)
+	assert.True(t, info.Fallback)
+	assert.True(t, info.Readable)
+
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.Equal(t, "/#good-anchor", mockDisplay.Options["URL"])
+	data, err := s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "# Test Anchor")
+
+	assert.Error(t, s.CheckSource(context.Background()))
+	assert.True(t, s.SourceInfo().Fallback)
+
+	down.Store(false)
+	mockDisplay.OpenCalled = false
+	assert.NoError(t, s.CheckSource(context.Background()))
+	assert.False(t, s.SourceInfo().Fallback)
+	// The open help window moves back to the remote documentation.
+	assert.True(t, mockDisplay.OpenCalled)
+	assert.Equal(t, server.URL+"/docs/#good-anchor", mockDisplay.Options["URL"])
+	assert.NoError(t, s.Show())
+	assert.Equal(t, server.URL+"/docs/", mockDisplay.Options["URL"])
+	data, err = s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Remote\n", string(data))
+}
+
+func TestFallbackAssets_FirstShow(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	s, _, mockDisplay := setupService(t, Options{
+		Source:         server.URL + "/docs/",
+		FallbackAssets: testDocs,
+	})
+
+	assert.NoError(t, s.Show())
+	assert.True(t, s.SourceInfo().Fallback)
+	_, hasURL := mockDisplay.Options["URL"]
+	assert.False(t, hasURL)
+}
+
+func TestFallbackAssets_NotNeeded(t *testing.T) {
+	server := newDocsServer(t)
+	s, _, _ := setupService(t, Options{
+		Source:         server.URL + "/docs",
+		FallbackAssets: testDocs,
+	})
+
+	assert.NoError(t, s.ServiceStartup(context.Background()))
+	assert.False(t, s.SourceInfo().Fallback)
+
+	s, _, _ = setupService(t, Options{Source: server.URL + "/missing"})
+	assert.Error(t, s.CheckSource(context.Background()))
+	assert.False(t, s.SourceInfo().Fallback)
+}
@@ -0,0 +1,107 @@
+package help
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// filterFS hides the documentation pages that `Options.PageFilter` rejects
+// from a documentation filesystem. Other files, such as images, are kept.
+type filterFS struct {
+	fs.FS
+	keep func(path string) bool
+}
+
+// withFilter returns fsys with the pages that keep rejects hidden, or fsys
+// itself if keep is nil or fsys is a remote source, which cannot be listed.
+func withFilter(fsys fs.FS, keep func(path string) bool) fs.FS {
+	if keep == nil {
+		return fsys
+	}
+	if _, ok := fsys.(*httpFS); ok {
+		return fsys
+	}
+	return &filterFS{FS: fsys, keep: keep}
+}
+
+// Open opens the named file, as if a hidden page did not exist.
+func (f *filterFS) Open(name string) (fs.File, error) {
+	if hiddenPage(name, f.keep) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return f.FS.Open(name)
+}
+
+// ReadDir lists the named directory, leaving out hidden pages.
+func (f *filterFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(f.FS, name)
+	if err != nil {
+		return nil, err
+	}
+	kept := entries[:0]
+	for _, entry := range entries {
+		if !hiddenPage(path.Join(name, entry.Name()), f.keep) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, nil
+}
+
+// hiddenPage reports whether name is a documentation page that keep
+// rejects.
+func hiddenPage(name string, keep func(path string) bool) bool {
+	return keep != nil && isDocFile(name) && !keep(name)
+}
+
+// pageHidden reports whether the page at name, relative to the current doc
+// set and locale, is hidden by `Options.PageFilter`. The name may be any
+// form that `findPage` accepts, such as "guide" for "guide/index.md".
+func (s *Service) pageHidden(name string) bool {
+	s.mu.Lock()
+	fsys := localize(s.assets, s.contentDir())
+	s.mu.Unlock()
+	return pageHiddenIn(fsys, name, s.opts.PageFilter)
+}
+
+// servedPageHidden reports whether the page that `Serve` provides at name,
+// relative to the documentation root, is hidden by `Options.PageFilter`.
+// A page within the current doc set and locale is checked by its path
+// relative to them, as `ListPages` reports it, and any other page, such as
+// one of the root language, by its path from the documentation root.
+func (s *Service) servedPageHidden(name string) bool {
+	s.mu.Lock()
+	dir := s.contentDir()
+	s.mu.Unlock()
+	name = strings.Trim(name, "/")
+	if rel, ok := withinDir(name, dir); ok {
+		return s.pageHidden(rel)
+	}
+	return pageHiddenIn(s.rootAssets(), name, s.opts.PageFilter)
+}
+
+// pageHiddenIn reports whether the page at name in fsys, in any form that
+// `findPage` accepts, is one that keep rejects.
+func pageHiddenIn(fsys fs.FS, name string, keep func(path string) bool) bool {
+	if keep == nil {
+		return false
+	}
+	name = strings.Trim(name, "/")
+	if hiddenPage(name, keep) {
+		return true
+	}
+	if _, ok := fsys.(*httpFS); ok {
+		return false
+	}
+	file, ok := findPage(fsys, name)
+	return ok && hiddenPage(file, keep)
+}
+
+// withinDir returns name relative to dir, and reports whether name is dir
+// or inside it.
+func withinDir(name, dir string) (string, bool) {
+	if dir == "" || name == dir {
+		return strings.TrimPrefix(name, dir), true
+	}
+	return strings.CutPrefix(name, dir+"/")
+}
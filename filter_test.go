@@ -0,0 +1,100 @@
+package help
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var filterDocs = NewMemorySource(map[string]string{
+	"index.md":       "# Home\n\n## Getting Started\n\nWelcome.\n",
+	"admin/users.md": "# Users\n\n## Manage Users\n\nWelcome, administrator.\n",
+	"admin/logo.png": "png",
+})
+
+func notAdmin(p string) bool { return !strings.HasPrefix(p, "admin/") }
+
+func TestPageFilter(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: filterDocs, PageFilter: notAdmin})
+
+	pages, err := s.ListPages()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"index.md"}, pages)
+
+	toc, err := s.TableOfContents()
+	assert.NoError(t, err)
+	for _, entry := range toc {
+		assert.NotEqual(t, "admin/users.md", entry.Path)
+	}
+
+	results, err := s.Search("welcome")
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "index.md", results[0].Path)
+	}
+
+	_, err = s.ReadPage("admin/users.md")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	err = s.ShowAt("admin/users#manage-users")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Error(t, s.ShowAt("manage-users"))
+	assert.Nil(t, mockDisplay.Options)
+
+	logo, err := s.ReadPage("admin/logo.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "png", string(logo))
+	assert.NoError(t, s.ShowAt("getting-started"))
+}
+
+func TestPageFilter_Serve(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: filterDocs, PageFilter: notAdmin})
+
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	resp, err := http.Get(url + "admin/users.md")
+	assert.NoError(t, err)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, get(t, url+"index.md"), "# Home")
+}
+
+func TestPageFilter_ServeOutsideLocale(t *testing.T) {
+	docs := NewMemorySource(map[string]string{
+		"index.md":          "# Home\n",
+		"admin/users.md":    "# Users\n",
+		"de/index.md":       "# Startseite\n",
+		"de/admin/users.md": "# Benutzer\n",
+	})
+	s, _, _ := setupService(t, Options{Assets: docs, Locale: "de", PageFilter: notAdmin})
+
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	for _, page := range []string{"de/admin/users.md", "admin/users.md", "admin/users.html"} {
+		resp, err := http.Get(url + page)
+		if assert.NoError(t, err) {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode, page)
+		}
+	}
+	assert.Contains(t, get(t, url+"index.md"), "# Home")
+}
+
+func TestPageFilter_Unset(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: filterDocs})
+
+	pages, err := s.ListPages()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin/users.md", "index.md"}, pages)
+	assert.NoError(t, s.ShowAt("admin/users#manage-users"))
+}
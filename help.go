@@ -19,7 +19,8 @@
 // architecture and making it easier to mock dependencies for testing.
 //
 // Usage:
-// To use the help service, create a new instance with `New()`, providing
+// In a core application, install the help service with `Register()`. For
+// more control, create a new instance with `New()`, providing
 // `Options` to configure the source of the help assets. The service can then
 // be initialized with a `Core` and `Display` implementation. The `Show()` and
 // `ShowAt()` methods can be called to display the help window or a specific
@@ -29,11 +30,18 @@ package help
 import (
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
-	"os"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
+	"github.com/wailsapp/wails/v3/pkg/events"
 )
 
 //go:embed all:public/*
@@ -68,10 +76,54 @@ type Core interface {
 	App() App
 }
 
-// Display defines the interface for a display service. The help service
-// uses this interface to check for the presence of a display module,
-// allowing it to function as an optional dependency.
-type Display interface{}
+// Display defines the interface for a display service, such as the one
+// provided by the `Snider/display` module. When a display is available, the
+// help service manages its window through these methods; when it is not, the
+// service falls back to driving `wails3` directly.
+type Display interface {
+	// OpenWindow opens the named window with the given options, or raises
+	// and updates it if it is already open. Options use the keys "Title",
+	// "Width", "Height", and "URL", plus "X" and "Y" for an explicit
+	// position, "Center" set to true to center the window, or "Maximised"
+	// set to true to maximize it, and "Icon" with the window icon as PNG
+	// bytes. They are built by
+	// `OpenWindowMessage.Map`.
+	OpenWindow(name string, options map[string]any) error
+	// HideWindow hides the named window without destroying it.
+	HideWindow(name string) error
+	// CloseWindow closes the named window and disposes of it.
+	CloseWindow(name string) error
+	// ReloadWindow reloads the content of the named window at its current
+	// URL.
+	ReloadWindow(name string) error
+}
+
+// NopDisplay is a `Display` whose methods do nothing and return nil. Embed it
+// in a struct to implement `Display` while overriding only the methods you
+// need, or use it in tests.
+//
+// Example:
+//
+//	type openOnly struct {
+//		help.NopDisplay
+//	}
+//
+//	func (openOnly) OpenWindow(name string, options map[string]any) error {
+//		return openHelpWindow(options["URL"].(string))
+//	}
+type NopDisplay struct{}
+
+// OpenWindow does nothing and returns nil.
+func (NopDisplay) OpenWindow(name string, options map[string]any) error { return nil }
+
+// HideWindow does nothing and returns nil.
+func (NopDisplay) HideWindow(name string) error { return nil }
+
+// CloseWindow does nothing and returns nil.
+func (NopDisplay) CloseWindow(name string) error { return nil }
+
+// ReloadWindow does nothing and returns nil.
+func (NopDisplay) ReloadWindow(name string) error { return nil }
 
 // Help defines the public interface of the help service. It exposes methods
 // for showing the help window and navigating to specific sections.
@@ -81,35 +133,361 @@ type Help interface {
 	// ShowAt displays a specific section of the help documentation,
 	// identified by an anchor.
 	ShowAt(anchor string) error
+	// ShowContext is like Show, but gives up when ctx is done.
+	ShowContext(ctx context.Context) error
+	// ShowAtContext is like ShowAt, but gives up when ctx is done.
+	ShowAtContext(ctx context.Context, anchor string) error
+	// Hide hides the help window, keeping it alive for a later Show.
+	Hide() error
+	// Close closes the help window and disposes of it.
+	Close() error
 	// ServiceStartup is a lifecycle method called when the application starts.
 	ServiceStartup(ctx context.Context) error
+	// ServiceShutdown is a lifecycle method called when the application
+	// shuts down.
+	ServiceShutdown() error
 }
 
 // Options holds the configuration for the help service. It allows for
 // customization of the help content source.
 type Options struct {
-	// Source specifies the directory or path to the help content.
-	// If empty, it defaults to "mkdocs".
+	// Source specifies the directory or path to the help content, the path
+	// of a ".zip" archive of it, or the `http://` or `https://` URL of a web
+	// server hosting it. If empty, it defaults to "mkdocs", the
+	// documentation embedded in this package or set with
+	// `SetDefaultAssets`.
 	Source string
+	// Sources lists several sources, each a directory, zip archive, URL,
+	// or "mkdocs", to be layered in order: a file is read from the first
+	// source that has it. This allows site-specific docs to override some
+	// pages of a default set, as in []string{"/etc/app/docs", "mkdocs"}.
+	// Missing directories are skipped. If set, Sources is used instead of
+	// Source.
+	Sources []string
+	// HTTPClient is the client used to fetch documentation from a remote
+	// source. Set it to add authentication headers, a proxy, or client
+	// certificates through its Transport. If nil, a client with a 30
+	// second timeout is used. Each fetch is given up after 30 seconds
+	// whichever client is used.
+	HTTPClient *http.Client
+	// CacheTTL keeps the pages fetched from a remote source in memory for
+	// this long, so that browsing them does not fetch every page again.
+	// Once it has passed, a page is revalidated with the server, using its
+	// ETag or Last-Modified header when it sent one, and fetched again only
+	// if it has changed. If zero, every read fetches the page.
+	CacheTTL time.Duration
+	// Logger is the logger the service reports to, such as an
+	// `*slog.Logger`, so that a lightweight integration does not need a
+	// core runtime to get one. If nil, the application logger of the core
+	// runtime is used, if any; otherwise messages are discarded. See
+	// `SetLogger`.
+	Logger Logger
 	// Assets provides an alternative way to specify the help content
 	// using a filesystem interface, which is useful for embedded assets.
 	Assets fs.FS
+	// FallbackAssets is the documentation used in place of a remote
+	// Source that cannot be reached, such as a copy embedded in the
+	// application, so that help works offline. The remote source is
+	// checked by `ServiceStartup`, or by the first `Show` if the service
+	// was not started, and the switch is logged. `CheckSource` switches
+	// back once the remote source is reachable again.
+	FallbackAssets fs.FS
+	// WindowTitle is the title of the help window. If empty, it defaults
+	// to "Help".
+	WindowTitle string
+	// WindowWidth is the width of the help window. If zero, it defaults
+	// to 800.
+	WindowWidth int
+	// WindowHeight is the height of the help window. If zero, it defaults
+	// to 600.
+	WindowHeight int
+	// WindowIcon is the help window's icon, as PNG bytes. It is sent to a
+	// `Display` with the window options, and set as the icon of the
+	// `wails3` fallback window, which supports per-window icons on Linux;
+	// elsewhere the application icon is used. If nil, the window has the
+	// default icon.
+	WindowIcon []byte
+	// WindowOptions customizes the help window created by the `wails3`
+	// fallback, for example to make it frameless or always on top. Its
+	// Title, Width, and Height take precedence over the fields above when
+	// set, and fall back to them when not. Name and URL are always set by
+	// the service. WindowOptions is not used when a `Display` is available.
+	WindowOptions application.WebviewWindowOptions
+	// WindowX and WindowY position the help window on screen. If both are
+	// zero, the default position is used.
+	WindowX int
+	WindowY int
+	// CenterOnShow centers the help window over the application's active
+	// window each time it is shown, or on the screen if there is none. It
+	// takes precedence over WindowX and WindowY.
+	CenterOnShow bool
+	// CloseOnEscape and CloseOnBlur close the help window when the user
+	// presses Escape in it, or when it loses focus, so that it behaves as
+	// a transient popup. Only that window is closed: other help windows
+	// and the `Serve` server are left running. They apply to the `wails3`
+	// fallback window; by default the window stays open until closed.
+	CloseOnEscape bool
+	CloseOnBlur   bool
+	// Headless lets the service run without a GUI, as in CI or integration
+	// tests. When there is neither a `Display` nor a running `wails3`
+	// application, `Show` and `ShowAt` log the URL they would open and
+	// return nil, and `Hide` and `Close` do nothing, instead of returning
+	// `ErrDisplayNotInitialized`. Anchors are still checked.
+	Headless bool
+	// Target is where help is shown: `TargetWindow`, the default, for an
+	// application window, or `TargetBrowser` for the system's default web
+	// browser. In the browser, documentation served by the application is
+	// provided by `Serve`, which is started on first use; `Hide` does
+	// nothing and `Close` stops the server.
+	Target string
+	// AnchorAliases maps deprecated anchors to their current targets, so
+	// that sections can be renamed without breaking existing calls to
+	// `ShowAt`: ShowAt("old-anchor") opens the section aliased to it, and
+	// logs a deprecation notice. Targets may name a page, as in
+	// "install.html#requirements". Aliases are not followed in chains.
+	AnchorAliases map[string]string
+	// DefaultAnchor is the section that `Show` opens, such as an overview,
+	// making it equivalent to `ShowAt(DefaultAnchor)`. If empty, Show opens
+	// the documentation root.
+	DefaultAnchor string
+	// ExtraCSS lists stylesheets to add to the head of every HTML page
+	// served by `Serve`. Each entry is either a URL, such as
+	// "/brand.css", or inline CSS.
+	ExtraCSS []string
+	// ExtraJS lists scripts to add to the end of the body of every HTML
+	// page served by `Serve`. Each entry is either a URL, such as
+	// "https://example.com/feedback.js", or inline JavaScript.
+	ExtraJS []string
+	// ContentSecurityPolicy is the Content-Security-Policy header sent
+	// with HTML pages served by `Serve`, which limits what the help window
+	// may load. If empty, a policy is used that allows resources from the
+	// documentation server only, including inline scripts and styles,
+	// plus the origins of ExtraCSS and ExtraJS given by URL. Set it to
+	// allow other origins, such as those of third-party embeds.
+	ContentSecurityPolicy string
+	// TemplateData holds values, such as the application's version or the
+	// user's plan, that documentation pages refer to with `text/template`
+	// directives like "{{.Version}}". Pages are expanded with it when
+	// rendered by `RenderPage` or served as HTML by `Serve`. If nil, or for
+	// pages without directives or whose directives are not valid, pages are
+	// shown unchanged. See `SetTemplateData`.
+	TemplateData map[string]any
+	// Renderer converts markdown pages to HTML for `RenderPage` and
+	// `SectionHTML`, for example to add diagram or math support. If nil,
+	// the built-in GitHub Flavored Markdown renderer is used, which also
+	// points links between markdown files at their pages in the help
+	// window. See `Renderer`.
+	Renderer Renderer
+	// Accessible opens the help window maximized and, on pages served by
+	// `Serve`, adds a stylesheet with a larger base font and high-contrast
+	// colors, for users with low vision. It keeps the color scheme chosen
+	// by Theme.
+	Accessible bool
+	// NavigateDebounce coalesces rapid calls to `ShowAt`, such as those
+	// fired while a "next section" shortcut is held down. If positive,
+	// ShowAt checks the anchor and returns at once, and the help window is
+	// opened at the last anchor requested once no call has been made for
+	// this long. If zero, every call opens the window immediately.
+	NavigateDebounce time.Duration
+	// ExportOverwrite allows `ExportPDF` to replace an existing file. If
+	// false, exporting to a path that already exists is an error.
+	ExportOverwrite bool
+	// Locale selects a translation of the documentation, kept in a
+	// directory of that name at the root of the source, such as "de" for
+	// `public/de/`. If empty, DefaultLocale is used. See `SetLocale`.
+	Locale string
+	// DefaultLocale is the locale used when Locale is empty or its
+	// directory does not exist. If it is empty too, or its directory does
+	// not exist either, the root of the source is used.
+	DefaultLocale string
+	// DocSet selects one of several documentation sets shipped in one
+	// source, each in a directory of its own, such as "app-a" for
+	// `public/app-a/`. Content, anchors, and help window URLs are all
+	// resolved within it, and Locale within it in turn. If empty, the root
+	// of the source is used. See `SetDocSet`.
+	DocSet string
+	// Theme is the color scheme of the help content: `ThemeLight`,
+	// `ThemeDark`, or `ThemeAuto` to follow the operating system. If empty,
+	// the documentation's own default is used. See `SetTheme`.
+	Theme string
+	// BasePath is the URL the help window opens for documentation served
+	// by the application, such as "/help/" when the app serves it under a
+	// sub-path, or "wails://help/" for a custom scheme. Anchors and pages
+	// are appended to it. It must start with "/" or a URL scheme, and a
+	// trailing "/" is added if missing. If empty, it defaults to "/". It
+	// is not used while `Serve` is running or for an HTTP source, which
+	// have URLs of their own.
+	BasePath string
+	// WindowReuse controls how many help windows the `wails3` fallback
+	// opens: `WindowReuseSingle`, the default, reuses one window for
+	// everything; `WindowReusePerAnchor` opens one window per section, so
+	// sections can be compared side by side, and reuses it when the same
+	// section is shown again; `WindowReuseAlwaysNew` opens a new window
+	// every time. `Hide`, `Close`, and `Reload` act on every help window.
+	WindowReuse string
+	// WindowNamePrefix keeps the help window's name unique when several
+	// help services share one core, such as one per plugin: with "pluginA",
+	// the window is named "pluginA-help" in the messages sent to the
+	// `Display` service and in the `wails3` fallback. If empty, the window
+	// is named "help".
+	WindowNamePrefix string
+	// ActionRetries is how many times a failed request to open or reload
+	// the help window through the `Display` service or core runtime is
+	// retried, for a runtime that briefly rejects them while starting up.
+	// If zero, a failed request is returned at once. The error of the last
+	// attempt is returned once the retries are used up. `ShowContext` and
+	// `ShowAtContext` stop retrying when their context is done.
+	ActionRetries int
+	// ActionRetryDelay is the wait before the first retry; it doubles
+	// before each one after. If zero, it defaults to 100ms.
+	ActionRetryDelay time.Duration
+	// MetricsFile is the path of a file that every navigation of the help
+	// window is appended to, as a `MetricEvent` in JSON on a line of its
+	// own, for usage analytics that stay on the machine. The file is
+	// created if needed. `help stats` summarizes it. If empty, navigations
+	// are not recorded.
+	MetricsFile string
+	// AppInfo adds an about page showing the application's name, version,
+	// and build to the documentation, as "about.md" at its root, so that
+	// every application using help has one in the same place. It opens
+	// with `ShowAt("about")`, is listed last in `TableOfContents`, and is
+	// served as HTML by `Serve`. Documentation with an "about.md" of its
+	// own keeps it. If empty, no page is added.
+	AppInfo AppInfo
+	// PageFilter decides which documentation pages are available, such as
+	// to hide admin pages from other users. It is called with the path of
+	// each markdown and HTML page, relative to the current doc set and
+	// locale as `ListPages` reports it, and the pages it returns false for
+	// are left out of `ListPages`, `TableOfContents`, `Search`, and
+	// `Anchors`, are not served by `Serve`, and cannot be opened:
+	// `ReadPage` and `ShowAt` return an error wrapping `ErrNotFound`.
+	// Pages that `Serve` provides from outside the current doc set and
+	// locale, such as those of the root language, are checked by their
+	// path from the documentation root.
+	// Other files, such as images, are not filtered. If nil, every page is
+	// available.
+	PageFilter func(path string) bool
+	// ParentWindow is the name of an application window, as given by its
+	// `Name` method, that the `wails3` fallback opens the help window as a
+	// child of, so that it stays above that window and is minimized with
+	// it. Child windows are supported on Windows; elsewhere, or if there is
+	// no window of that name, the help window opens as a top-level window
+	// and a warning is logged. If empty, the help window is top-level.
+	ParentWindow string
+	// AutoCloseAfter closes the help window once it has been open this long
+	// without navigation, so that forgotten windows do not pile up, such as
+	// in kiosk deployments. The timer starts when the window is shown and
+	// restarts with every `ShowAt` and other navigation; when it fires, the
+	// window is closed as by `Close`. If zero, the window stays open until
+	// closed.
+	AutoCloseAfter time.Duration
 }
 
 // Service manages the in-app help system. It handles the initialization
 // of the help content, interaction with the core runtime, and display
-// of the help window.
+// of the help window. Once configured with `Init` or `RegisterWails`, a
+// Service is safe for concurrent use: `Show` and `ShowAt` in particular may
+// be called from several goroutines at once, such as a tray menu and a
+// hotkey, and still share a single help window.
 type Service struct {
 	core    Core
 	display Display
 	assets  fs.FS
 	opts    Options
+
+	// app is the wails application given to `RegisterWails`. When it is
+	// nil, the fallback uses the running application, if any. windowing,
+	// if set, creates the fallback's windows in place of the application.
+	app       *application.App
+	windowing windowFactory
+
+	// mu guards assets, the windows, and the navigation history. windows
+	// holds the help windows created by the wails3 fallback, keyed as set
+	// by WindowReuse, and window is the one shown most recently; windowSeq
+	// numbers the keys of windows that are never reused, and urls holds
+	// the URL each window was last pointed at.
+	// history holds the anchors visited with ShowAt, with historyPos
+	// indexing the current one.
+	mu         sync.Mutex
+	window     helpWindow
+	windows    map[string]helpWindow
+	urls       map[string]string
+	windowSeq  int
+	history    []string
+	historyPos int
+
+	// offline is the remote source set aside while FallbackAssets are used
+	// in its place, and sourceChecked records whether CheckSource has run.
+	// They are guarded by mu.
+	offline       *httpFS
+	sourceChecked bool
+
+	// locale is the locale directory in use, resolved from the Locale and
+	// DefaultLocale options; it is empty for the root of the source. It is
+	// guarded by mu.
+	locale string
+
+	// log is the logger set with SetLogger, if any. It is guarded by mu.
+	log Logger
+
+	// tour is the guided tour in progress, if any. It is guarded by mu.
+	tour *tour
+
+	// displayOpen records whether the help window has been opened through
+	// the Display service and not closed since. It is guarded by mu.
+	displayOpen bool
+
+	// navTimer, navAnchor, and navAt hold a navigation delayed by
+	// NavigateDebounce, and are guarded by mu.
+	navTimer  *time.Timer
+	navAnchor string
+	navAt     *windowPoint
+
+	// navMu is held while the help window is navigated, so that
+	// navigations run one at a time. It is taken before mu.
+	navMu sync.Mutex
+
+	// closeTimer closes the help window after AutoCloseAfter without
+	// navigation, and is guarded by mu.
+	closeTimer *time.Timer
+
+	// ctx is the context passed to ServiceStartup. The documentation server
+	// started by Serve, if any, is tracked in server, serveURL, and
+	// serveStop, and is guarded by mu.
+	ctx       context.Context
+	server    *http.Server
+	serveURL  string
+	serveStop chan struct{}
+
+	// watchCancels cancels the Watch calls in progress, by an ID taken
+	// from watchID, for Shutdown. Both are guarded by mu.
+	watchCancels map[int]context.CancelFunc
+	watchID      int
+
+	// indexMu guards index, the cached documentation index built by Warm.
+	indexMu sync.Mutex
+	index   *contentIndex
+
+	// hooksMu guards the lifecycle handlers registered with OnShow and
+	// OnClose, the IPC action handlers added with RegisterHandler, the
+	// channels of ShowModal calls waiting for the help window to close, and
+	// those of ShowAndWait calls waiting for its page to load.
+	hooksMu        sync.Mutex
+	showHandlers   []func(anchor string)
+	closeHandlers  []func()
+	actionHandlers map[string]func(msg map[string]any) error
+	closeWaiters   []chan struct{}
+	readyWaiters   []chan struct{}
+
+	// metricsMu serializes appends to Options.MetricsFile.
+	metricsMu sync.Mutex
 }
 
 // New creates a new instance of the help Service. It initializes the service
 // with the provided options, setting up the asset filesystem based on the
 // specified source. If no source is provided, it defaults to the embedded
-// "mkdocs" content.
+// "mkdocs" content. Unset window options default to an 800x600 window
+// titled "Help".
 //
 // Example:
 //
@@ -122,25 +500,152 @@ func New(opts Options) (*Service, error) {
 	if opts.Source == "" {
 		opts.Source = "mkdocs"
 	}
+	if opts.WindowTitle == "" {
+		opts.WindowTitle = "Help"
+	}
+	if opts.WindowWidth == 0 {
+		opts.WindowWidth = 800
+	}
+	if opts.WindowHeight == 0 {
+		opts.WindowHeight = 600
+	}
+	if opts.BasePath == "" {
+		opts.BasePath = "/"
+	}
+	if !strings.HasSuffix(opts.BasePath, "/") {
+		opts.BasePath += "/"
+	}
+	if opts.AnchorAliases != nil {
+		aliases := make(map[string]string, len(opts.AnchorAliases))
+		for from, to := range opts.AnchorAliases {
+			aliases[normalizeAnchor(from)] = to
+		}
+		opts.AnchorAliases = aliases
+	}
+
+	if err := checkTheme(opts.Theme); err != nil {
+		return nil, err
+	}
+	if err := checkBasePath(opts.BasePath); err != nil {
+		return nil, err
+	}
+	if err := checkTarget(opts.Target); err != nil {
+		return nil, err
+	}
+	if err := checkWindowReuse(opts.WindowReuse); err != nil {
+		return nil, err
+	}
+	if err := checkLocale(opts.Locale); err != nil {
+		return nil, err
+	}
+	if err := checkLocale(opts.DefaultLocale); err != nil {
+		return nil, err
+	}
+	if err := checkDocSet(opts.DocSet); err != nil {
+		return nil, err
+	}
 
-	s := &Service{
-		opts: opts,
+	assets, err := resolveAssets(opts)
+	if err != nil {
+		return nil, err
 	}
+	return &Service{
+		opts:       opts,
+		assets:     assets,
+		locale:     pickLocale(localize(assets, opts.DocSet), opts.Locale, opts.DefaultLocale),
+		historyPos: -1,
+	}, nil
+}
 
-	var err error
-	if opts.Assets != nil {
-		s.assets = opts.Assets
-	} else if s.opts.Source != "mkdocs" {
-		s.assets = os.DirFS(s.opts.Source)
-	} else {
-		s.assets, err = fs.Sub(helpStatic, "public")
-		if err != nil {
-			return nil, err
-		}
+// Register creates a help service with the default options, wires it to the
+// core runtime, and returns it as a `Help`. It is the usual way to install
+// the help service in a core application; use `New` and `Init` directly for
+// more control. If c also implements `Display`, the help window is opened
+// through it; otherwise the `wails3` fallback is used. If c implements
+// `ActionRegistrar`, the service's `HandleIPCEvents` is registered to
+// receive the runtime's action messages.
+//
+// Example:
+//
+//	helpService, err := help.Register(runtime)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = helpService.Show()
+func Register(c Core) (Help, error) {
+	if c == nil {
+		return nil, ErrCoreNotInitialized
+	}
+	s, err := New(Options{})
+	if err != nil {
+		return nil, err
+	}
+	display, _ := c.(Display)
+	s.Init(c, display)
+	if registrar, ok := c.(ActionRegistrar); ok {
+		registrar.RegisterAction(s.HandleIPCEvents)
 	}
 	return s, nil
 }
 
+// RegisterWails creates a help service for a `wails3` application that has no
+// core runtime. The service always uses the `wails3` fallback path, opening
+// the help window through app, and is registered with app as a wails service
+// so that its methods are bound for the frontend. Its lifecycle methods are
+// registered too, so that app calls `ServiceStartup` and `ServiceShutdown`.
+// It must be called before the application runs. If app is nil, it returns
+// `ErrWailsNotRunning`.
+//
+// Example:
+//
+//	app := application.New(application.Options{Name: "My App"})
+//	helpService, err := help.RegisterWails(app)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = helpService.Show()
+func RegisterWails(app *application.App) (Help, error) {
+	if app == nil {
+		return nil, ErrWailsNotRunning
+	}
+	s, err := New(Options{})
+	if err != nil {
+		return nil, err
+	}
+	s.app = app
+	app.RegisterService(application.NewService(s))
+	app.RegisterService(application.NewService(&wailsLifecycle{s}))
+	return s, nil
+}
+
+// wailsLifecycle adapts the lifecycle methods of a `Service` to those a
+// `wails3` application calls, which take the service options as well. It
+// is registered as a service of its own, so the bindings of the Service
+// keep their names.
+type wailsLifecycle struct {
+	s *Service
+}
+
+// ServiceStartup starts the service; see `Service.ServiceStartup`.
+func (l *wailsLifecycle) ServiceStartup(ctx context.Context, _ application.ServiceOptions) error {
+	return l.s.ServiceStartup(ctx)
+}
+
+// ServiceShutdown releases the service's resources; see
+// `Service.ServiceShutdown`.
+func (l *wailsLifecycle) ServiceShutdown() error {
+	return l.s.ServiceShutdown()
+}
+
+// wailsApp returns the application used by the `wails3` fallback: the one
+// given to `RegisterWails`, or the running application otherwise.
+func (s *Service) wailsApp() *application.App {
+	if s.app != nil {
+		return s.app
+	}
+	return application.Get()
+}
+
 // Init initializes the service with its core dependencies. This method is
 // intended to be called by the dependency injection system of the application
 // to provide the necessary `Core` and `Display` implementations.
@@ -149,89 +654,819 @@ func (s *Service) Init(c Core, d Display) {
 	s.display = d
 }
 
+// Modes reported by `Mode`.
+const (
+	// ModeDisplay means the help window is managed by the `Display` service.
+	ModeDisplay = "display"
+	// ModeWails means the help window is managed directly through `wails3`.
+	ModeWails = "wails"
+	// ModeUninitialized means neither a `Display` service nor a running
+	// `wails3` application is available, so the help window cannot be shown.
+	ModeUninitialized = "uninitialized"
+)
+
+// HasDisplay reports whether the service has a `Display` to open the help
+// window through, rather than using the `wails3` fallback.
+func (s *Service) HasDisplay() bool {
+	return s.display != nil
+}
+
+// Mode reports how the help window will be shown: `ModeDisplay` when a
+// `Display` is available, `ModeWails` when the `wails3` fallback will be used,
+// or `ModeUninitialized` when neither is available.
+//
+// Example:
+//
+//	if helpService.Mode() == help.ModeUninitialized {
+//		log.Println("help is not available yet")
+//	}
+func (s *Service) Mode() string {
+	switch {
+	case s.display != nil:
+		return ModeDisplay
+	case s.windowFactory() != nil:
+		return ModeWails
+	default:
+		return ModeUninitialized
+	}
+}
+
+// Ready reports whether the service is ready to show help: it has a way to
+// open the help window, being a `Display` with a core runtime given to
+// `Init`, or a running `wails3` application, or it shows help in the system
+// browser, and it has a documentation source. If the documentation index
+// has been built, such as by `ServiceStartup`, the source must also have
+// pages. Ready does no I/O, so it is cheap enough to call when enabling a
+// Help button.
+//
+// Example:
+//
+//	helpButton.SetEnabled(helpService.Ready())
+func (s *Service) Ready() bool {
+	switch {
+	case s.inBrowser():
+	case s.display != nil:
+		if s.core == nil {
+			return false
+		}
+	case s.windowFactory() == nil:
+		return false
+	}
+
+	s.mu.Lock()
+	assets := s.assets
+	s.mu.Unlock()
+	if assets == nil {
+		return false
+	}
+	if _, remote := assets.(*httpFS); remote {
+		return true
+	}
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	return s.index == nil || len(s.index.paths) > 0
+}
+
 // ServiceStartup is a lifecycle method that is called by the application when
 // it starts. It performs necessary checks to ensure that the service has been
-// properly initialized with its dependencies.
-func (s *Service) ServiceStartup(context.Context) error {
-	if s.core == nil {
-		return fmt.Errorf("core runtime not initialized")
+// properly initialized with its dependencies, and that the documentation
+// source is usable; see `Validate`. The source in use is logged; see
+// `SourceInfo`. The documentation index is then built in the background; see
+// `Warm`. Messages go to `Options.Logger`, if set, rather than to the core
+// runtime's logger. If no core runtime has been given to `Init`, and the
+// service was not created with `RegisterWails`, it returns
+// `ErrCoreNotInitialized`.
+func (s *Service) ServiceStartup(ctx context.Context) error {
+	if s.core == nil && s.app == nil {
+		return ErrCoreNotInitialized
+	}
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+	if info := s.sourceInfo(); info.Location != "" {
+		s.logger().Info(fmt.Sprintf("help: using %s source %s", info.Kind, info.Location))
+	} else {
+		s.logger().Info(fmt.Sprintf("help: using %s source", info.Kind))
 	}
-	s.core.App().Logger().Info("Help service started")
+	if err := s.validate(ctx); err != nil {
+		return err
+	}
+	s.warnLocale()
+	go func() {
+		if err := s.Warm(ctx); err != nil && ctx.Err() == nil {
+			s.logger().Error(fmt.Sprintf("help: warm index: %v", err))
+		}
+	}()
+	s.logger().Info("Help service started")
 	return nil
 }
 
 // Show displays the main help window. If a `Display` service is available,
-// it sends an action to the core runtime to open the window. Otherwise, it
-// falls back to using the `wails3` application instance to create a new
-// window. This ensures that the help functionality is available even when
-// the `Snider/display` module is not in use.
+// it asks the display to open the window. Otherwise, it falls back to using
+// the `wails3` application instance to create the help window, or to raise it
+// if it is already open. For an HTTP source the window is pointed at the
+// remote URL directly. This ensures that the help functionality is available
+// even when the `Snider/display` module is not in use. If
+// `Options.DefaultAnchor` is set, Show opens that section instead of the
+// documentation root. Show is safe for concurrent use.
 func (s *Service) Show() error {
+	return s.ShowContext(context.Background())
+}
+
+// ShowContext is like `Show`, but returns ctx's error if ctx is cancelled or
+// its deadline passes before the help window has been opened, so that a slow
+// display service or documentation source cannot block the caller. If ctx is
+// done while another navigation is still in progress, the window is not
+// opened later.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+//	defer cancel()
+//	if err := helpService.ShowContext(ctx); err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) ShowContext(ctx context.Context) error {
+	s.mu.Lock()
+	anchor := s.opts.DefaultAnchor
+	s.mu.Unlock()
+	if anchor != "" {
+		return s.ShowAtContext(ctx, anchor)
+	}
+	s.checkSourceOnce(ctx)
+	if err := withContext(ctx, func() error {
+		return s.open(ctx)
+	}); err != nil {
+		return err
+	}
+	s.emitShow("show", "")
+	return nil
+}
+
+// ShowWindow is like `Show`, but also returns the help window created or
+// raised by the `wails3` fallback, so that the caller can manage it further,
+// for example to move it or listen for its events. When the window is opened
+// through a `Display` service there is no window handle, and ShowWindow
+// returns nil with no error.
+//
+// Example:
+//
+//	window, err := helpService.ShowWindow()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if window != nil {
+//		window.SetAlwaysOnTop(true)
+//	}
+func (s *Service) ShowWindow() (*application.WebviewWindow, error) {
+	if err := s.Show(); err != nil {
+		return nil, err
+	}
+	if s.display != nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	window, _ := s.window.(*application.WebviewWindow)
+	return window, nil
+}
+
+// open opens the help window at the documentation root. The dispatch to a
+// `Display` service is retried until ctx is done; see `dispatch`. Like
+// `openURLAt`, it waits for other navigations and does nothing if ctx is
+// done by then.
+func (s *Service) open(ctx context.Context) error {
+	s.navMu.Lock()
+	defer s.navMu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if s.inBrowser() {
+		return s.showInBrowser(s.pageURL(""))
+	}
 	if s.display == nil {
-		app := application.Get()
-		if app == nil {
-			return fmt.Errorf("wails application not running")
-		}
-		app.Window.NewWithOptions(application.WebviewWindowOptions{
-			Title:  "Help",
-			Width:  800,
-			Height: 600,
-			URL:    "/",
-		})
-		return nil
+		return s.showWindow(s.pageURL(""))
 	}
 	if s.core == nil {
-		return fmt.Errorf("core runtime not initialized")
+		return ErrCoreNotInitialized
 	}
-	msg := map[string]any{
-		"action": "display.open_window",
-		"name":   "help",
-		"options": map[string]any{
-			"Title":  "Help",
-			"Width":  800,
-			"Height": 600,
-		},
+	msg := s.openWindowMessage()
+	if url := s.pageURL(""); url != "/" {
+		msg.URL = url
 	}
-
-	return s.core.ACTION(msg)
+	return s.openDisplayWindow(ctx, msg)
 }
 
 // ShowAt displays a specific section of the help documentation, identified
 // by an anchor. Similar to `Show`, it uses the `Display` service if available,
 // or falls back to a direct `wails3` implementation. The anchor is appended
 // to the URL, allowing the help window to open directly to the relevant
-// section.
+// section. For multi-page documentation, the anchor may name a page too, as
+// in `ShowAt("installation.html#requirements")`; see `ShowPage`. An error is
+// returned if the anchor does not exist in the documentation, or in the
+// named page; see `Anchors`. Leading `#` characters are ignored, so
+// `ShowAt("#intro")` and `ShowAt("intro")` are equivalent, and an empty or
+// blank anchor is an error. Renamed anchors can be redirected with
+// `Options.AnchorAliases`. Each successful call is recorded in the
+// navigation history used by `Back` and `Forward`. Rapid calls can be
+// coalesced into one navigation with `Options.NavigateDebounce`. ShowAt is
+// safe for concurrent use: unless `Options.WindowReuse` says otherwise,
+// concurrent calls share one help window, which ends up at the anchor of
+// whichever call ran last.
 func (s *Service) ShowAt(anchor string) error {
+	return s.ShowAtContext(context.Background(), anchor)
+}
+
+// ShowAtContext is like `ShowAt`, but returns ctx's error if ctx is
+// cancelled or its deadline passes before the help window has been opened at
+// anchor. The anchor is only recorded in the navigation history if the
+// window was opened in time, and if ctx is done while another navigation is
+// still in progress, the window is not opened at anchor later. When
+// `Options.NavigateDebounce` is set, the window is opened later and ctx is
+// not used.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+//	defer cancel()
+//	if err := helpService.ShowAtContext(ctx, "getting-started"); err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) ShowAtContext(ctx context.Context, anchor string) error {
+	return s.showAt(ctx, anchor, nil)
+}
+
+// showAt opens the help window at anchor, as `ShowAtContext` does, and
+// places it at at if it is not nil.
+func (s *Service) showAt(ctx context.Context, anchor string, at *windowPoint) error {
+	anchor = s.resolveAnchor(anchor)
+	if _, fragment, _ := splitTarget(anchor); fragment == "" {
+		return fmt.Errorf("help: anchor must not be empty")
+	}
+	s.checkSourceOnce(ctx)
+	s.mu.Lock()
+	debounce := s.opts.NavigateDebounce > 0
+	s.mu.Unlock()
+	if debounce {
+		return s.debounceShowAt(anchor, at)
+	}
+	err := withContext(ctx, func() error {
+		return s.openAtQuery(ctx, anchor, nil, at)
+	})
+	if err != nil {
+		return err
+	}
+	s.pushHistory(anchor)
+	s.emitShow("show_at", anchor)
+	return nil
+}
+
+// ShowPage displays the section identified by anchor on a page of
+// multi-page documentation, such as the pages built by mkdocs. It is
+// equivalent to `ShowAt(page + "#" + anchor)`. The page may be given as the
+// documentation file or as its site URL, so "installation.md",
+// "installation.html", and "installation" all refer to the same page. An
+// error is returned if the page does not exist, or the anchor is not defined
+// in it.
+//
+// Example:
+//
+//	err := helpService.ShowPage("installation.html", "requirements")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (s *Service) ShowPage(page, anchor string) error {
+	page = strings.TrimSpace(page)
+	if strings.Trim(page, "/") == "" {
+		return fmt.Errorf("help: page must not be empty")
+	}
+	anchor = normalizeAnchor(anchor)
+	if anchor == "" {
+		return fmt.Errorf("help: anchor must not be empty")
+	}
+	return s.ShowAt(page + "#" + anchor)
+}
+
+// openAt opens the help window at anchor without recording it in the
+// navigation history. The dispatch to a `Display` service is retried until
+// ctx is done; see `dispatch`.
+func (s *Service) openAt(ctx context.Context, anchor string) error {
+	return s.openAtQuery(ctx, anchor, nil, nil)
+}
+
+// openAtQuery is like `openAt`, but adds params to the query of the URL
+// opened, and places the window at at if it is not nil.
+func (s *Service) openAtQuery(ctx context.Context, anchor string, params url.Values, at *windowPoint) error {
+	switch {
+	case s.inBrowser():
+	case s.display == nil && s.windowFactory() == nil && !s.opts.Headless:
+		return errNoWindowing
+	case s.display != nil && s.core == nil:
+		return ErrCoreNotInitialized
+	}
+	link, err := s.LinkFor(anchor)
+	if err != nil {
+		return err
+	}
+	return s.openURLAt(ctx, withQuery(link, params), at)
+}
+
+// openURL opens the help window at url, through the `Display` service if
+// available, or the `wails3` fallback otherwise. With `TargetBrowser`, url
+// is opened in the system browser instead.
+func (s *Service) openURL(ctx context.Context, url string) error {
+	return s.openURLAt(ctx, url, nil)
+}
+
+// openURLAt is like `openURL`, but places the window at at if it is not
+// nil. The browser has no window to place. Navigations run one at a time:
+// openURLAt waits for any other to finish, then returns ctx's error without
+// navigating if ctx is done, so that a caller that has stopped waiting, as
+// `ShowAtContext` does, is not followed by a late navigation.
+func (s *Service) openURLAt(ctx context.Context, url string, at *windowPoint) error {
+	s.navMu.Lock()
+	defer s.navMu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if s.inBrowser() {
+		return s.showInBrowser(url)
+	}
 	if s.display == nil {
-		app := application.Get()
-		if app == nil {
-			return fmt.Errorf("wails application not running")
-		}
-		url := fmt.Sprintf("/#%s", anchor)
-		app.Window.NewWithOptions(application.WebviewWindowOptions{
-			Title:  "Help",
-			Width:  800,
-			Height: 600,
-			URL:    url,
-		})
+		return s.showWindowAt(url, at)
+	}
+	if s.core == nil {
+		return ErrCoreNotInitialized
+	}
+	msg := s.openWindowMessage()
+	msg.URL = url
+	if at != nil {
+		msg.Center = false
+		msg.X, msg.Y = clampToScreen(s.wailsApp(), *at, msg.Width, msg.Height)
+	}
+	return s.openDisplayWindow(ctx, msg)
+}
+
+// openDisplayWindow asks the `Display` service to open the help window as
+// described by msg, retrying as set by `Options.ActionRetries` until ctx is
+// done, and records that the window is open.
+func (s *Service) openDisplayWindow(ctx context.Context, msg OpenWindowMessage) error {
+	err := s.dispatch(ctx, func() error {
+		return s.display.OpenWindow(msg.Name, msg.Map())
+	})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.displayOpen = true
+	s.mu.Unlock()
+	return nil
+}
+
+// openWindowMessage returns the message for opening the help window through
+// a `Display` service, without its URL.
+func (s *Service) openWindowMessage() OpenWindowMessage {
+	return OpenWindowMessage{
+		Name:      s.windowName(),
+		Title:     s.opts.WindowTitle,
+		Width:     s.opts.WindowWidth,
+		Height:    s.opts.WindowHeight,
+		X:         s.opts.WindowX,
+		Y:         s.opts.WindowY,
+		Center:    s.opts.CenterOnShow,
+		Maximised: s.opts.Accessible,
+		Icon:      s.opts.WindowIcon,
+	}
+}
+
+// Hide hides the help window without destroying it, so that a later call to
+// `Show` or `ShowAt` can bring it back. If a `Display` service is available,
+// it asks the display to hide the window. Otherwise, it hides the help window
+// tracked by the `wails3` fallback. Hiding when no help window is open is a
+// no-op.
+func (s *Service) Hide() error {
+	if s.inBrowser() {
+		return nil
+	}
+	if s.display == nil {
+		if s.windowFactory() == nil && !s.opts.Headless {
+			return errNoWindowing
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, window := range s.windows {
+			window.Hide()
+		}
+		return nil
+	}
+	if s.core == nil {
+		return ErrCoreNotInitialized
+	}
+	return s.display.HideWindow(s.windowName())
+}
+
+// Close closes the help window and releases it. Unlike `Hide`, the window is
+// fully disposed of and a later `Show` creates a new one. If a `Display`
+// service is available, it asks the display to close the window. Otherwise,
+// it closes the help window tracked by the `wails3` fallback. Closing when no
+// help window is open is a no-op. Close also shuts down the documentation
+// server started by `Serve`, if any, closes a zip archive source until help
+// is next read, cancels a navigation delayed by `Options.NavigateDebounce`,
+// and stops the `Options.AutoCloseAfter` timer.
+func (s *Service) Close() error {
+	s.cancelNavigate()
+	s.stopAutoClose()
+	if s.inBrowser() {
+		return s.release()
+	}
+	if s.display == nil {
+		if s.windowFactory() == nil && !s.opts.Headless {
+			return errNoWindowing
+		}
+		s.mu.Lock()
+		windows := s.windows
+		s.window, s.windows, s.urls = nil, nil, nil
+		s.mu.Unlock()
+		for _, window := range windows {
+			window.Close()
+		}
+		if len(windows) > 0 {
+			s.emitClose()
+		}
+		return s.release()
+	}
+	if s.core == nil {
+		return ErrCoreNotInitialized
+	}
+	if err := s.display.CloseWindow(s.windowName()); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.displayOpen = false
+	s.mu.Unlock()
+	s.emitClose()
+	return s.release()
+}
+
+// CloseAll closes every help window, for a tidy cleanup when the user logs
+// out or the application goes to the background: each window opened by the
+// `wails3` fallback, such as those kept by `Options.WindowReuse`, and the
+// window of the `Display` service, if there is one. Like `Close`, it then
+// shuts down the documentation server and closes a zip archive source.
+// Unlike Close, a failure does not stop the cleanup: every step is tried,
+// and their errors are returned together, joined with `errors.Join`. With
+// nothing open, or no windowing system at all, it does nothing.
+//
+// Example:
+//
+//	app.OnLogout(func() {
+//		if err := helpService.CloseAll(); err != nil {
+//			log.Println(err)
+//		}
+//	})
+func (s *Service) CloseAll() error {
+	s.cancelNavigate()
+	s.mu.Lock()
+	windows := s.windows
+	s.window, s.windows, s.urls = nil, nil, nil
+	s.mu.Unlock()
+	for _, window := range windows {
+		window.Close()
+	}
+	closed := len(windows) > 0
+
+	var errs []error
+	if s.display != nil && !s.inBrowser() {
+		if s.core == nil {
+			errs = append(errs, ErrCoreNotInitialized)
+		} else if err := s.display.CloseWindow(s.windowName()); err != nil {
+			errs = append(errs, err)
+		} else {
+			s.mu.Lock()
+			s.displayOpen = false
+			s.mu.Unlock()
+			closed = true
+		}
+	}
+	if closed {
+		s.emitClose()
+	}
+	if err := s.release(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// release shuts down the documentation server started by `Serve`, if any,
+// and closes a zip archive source, once the help window has been closed.
+func (s *Service) release() error {
+	err := s.stopServing()
+	if closeErr := closeAssets(s.rootAssets()); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Reload reloads the content of the open help window, so that documentation
+// updated on disk or by an external sync shows up without reopening it. If a
+// `Display` service is available, it asks the display to reload the window.
+// Otherwise, it reloads the help windows tracked by the `wails3` fallback.
+// Reloading when no help window is open is a no-op.
+//
+// Example:
+//
+//	if err := helpService.Reload(); err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) Reload() error {
+	s.mu.Lock()
+	windows := make([]helpWindow, 0, len(s.windows))
+	for _, window := range s.windows {
+		windows = append(windows, window)
+	}
+	displayOpen := s.displayOpen
+	s.mu.Unlock()
+	if s.display == nil {
+		for _, window := range windows {
+			window.Reload()
+		}
+		return nil
+	}
+	if !displayOpen {
 		return nil
 	}
 	if s.core == nil {
-		return fmt.Errorf("core runtime not initialized")
+		return ErrCoreNotInitialized
+	}
+	return s.dispatch(context.Background(), func() error {
+		return s.display.ReloadWindow(s.windowName())
+	})
+}
+
+// showWindow navigates the `wails3` fallback help window to url. Windows are
+// created on first use and tracked on the service under the key given by
+// `windowKey`, so repeated calls raise and focus an existing window rather
+// than stacking new ones, unless `Options.WindowReuse` asks for a new window
+// each time. A tracked window is forgotten when it closes, so the next call
+// for its key creates a fresh one.
+func (s *Service) showWindow(url string) error {
+	return s.showWindowAt(url, nil)
+}
+
+// showWindowAt is like `showWindow`, but places the window at at if it is
+// not nil, instead of where `Options.CenterOnShow` or the window options
+// would.
+func (s *Service) showWindowAt(url string, at *windowPoint) error {
+	app := s.wailsApp()
+	windows := s.windowFactory()
+	if windows == nil {
+		if s.opts.Headless {
+			s.logger().Info(fmt.Sprintf("help: headless, not opening %s", url))
+			return nil
+		}
+		return errNoWindowing
+	}
+
+	var parentErr error
+	defer func() {
+		if parentErr != nil {
+			s.logger().Error(fmt.Sprintf("%v; opening a top-level help window", parentErr))
+		}
+	}()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.windowKey(url)
+	if window := s.windows[key]; window != nil {
+		samePage := pageOf(s.urls[key]) == pageOf(url)
+		s.urls[key] = url
+		window.SetURL(url)
+		if samePage {
+			// Moving within the loaded page does not load it again.
+			s.emitReady()
+		}
+		switch {
+		case at != nil:
+			width, height := window.Size()
+			window.SetPosition(clampToScreen(app, *at, width, height))
+		case s.opts.CenterOnShow:
+			centerWindow(windows, window)
+		}
+		window.Show()
+		window.Focus()
+		s.window = window
+		return nil
+	}
+
+	options := s.windowOptions(url)
+	if at != nil {
+		options.InitialPosition = application.WindowXY
+		options.X, options.Y = clampToScreen(app, *at, options.Width, options.Height)
+	}
+	window := windows.NewWithOptions(options)
+	parentErr = s.attachToParent(windows, window)
+	window.OnWindowEvent(events.Common.WindowRuntimeReady, func(*application.WindowEvent) {
+		s.emitReady()
+	})
+	window.OnWindowEvent(events.Common.WindowClosing, func(*application.WindowEvent) {
+		s.mu.Lock()
+		tracked, last := s.untrackWindow(window)
+		s.mu.Unlock()
+		if tracked && last {
+			s.emitClose()
+		}
+	})
+	if s.opts.CloseOnBlur {
+		window.OnWindowEvent(events.Common.WindowLostFocus, func(*application.WindowEvent) {
+			s.closeWindow(window)
+		})
+	}
+	if s.windows == nil {
+		s.windows = make(map[string]helpWindow)
+		s.urls = make(map[string]string)
+	}
+	s.windows[key] = window
+	s.urls[key] = url
+	s.window = window
+	return nil
+}
+
+// Window reuse modes accepted by `Options.WindowReuse`.
+const (
+	// WindowReuseSingle shows all help in one window.
+	WindowReuseSingle = "single"
+	// WindowReusePerAnchor opens one help window per section.
+	WindowReusePerAnchor = "per-anchor"
+	// WindowReuseAlwaysNew opens a new help window each time help is shown.
+	WindowReuseAlwaysNew = "always-new"
+)
+
+// checkWindowReuse returns an error if mode is not a supported window reuse
+// mode.
+func checkWindowReuse(mode string) error {
+	switch mode {
+	case "", WindowReuseSingle, WindowReusePerAnchor, WindowReuseAlwaysNew:
+		return nil
 	}
+	return fmt.Errorf("help: invalid window reuse mode %q", mode)
+}
+
+// windowKey returns the key under which the help window showing url is
+// tracked: the same key for every url with `WindowReuseSingle`, the url
+// itself with `WindowReusePerAnchor`, and a key not used before with
+// `WindowReuseAlwaysNew`. It must be called with mu held.
+func (s *Service) windowKey(url string) string {
+	switch s.opts.WindowReuse {
+	case WindowReusePerAnchor:
+		return url
+	case WindowReuseAlwaysNew:
+		s.windowSeq++
+		return fmt.Sprintf("#%d", s.windowSeq)
+	}
+	return ""
+}
+
+// untrackWindow forgets window, and reports whether it was tracked and
+// whether it was the last help window. If it was the most recently shown
+// window, another tracked window takes its place. It must be called with mu
+// held.
+func (s *Service) untrackWindow(window helpWindow) (tracked, last bool) {
+	for key, w := range s.windows {
+		if w == window {
+			delete(s.windows, key)
+			delete(s.urls, key)
+			tracked = true
+		}
+	}
+	if s.window == window {
+		s.window = nil
+		for _, w := range s.windows {
+			s.window = w
+			break
+		}
+	}
+	return tracked, len(s.windows) == 0
+}
 
-	url := fmt.Sprintf("/#%s", anchor)
+// closeWindow closes window, a help window of the `wails3` fallback, in
+// response to Escape or a loss of focus. Unlike `Close`, the other help
+// windows and the documentation server are left running.
+func (s *Service) closeWindow(window helpWindow) {
+	s.mu.Lock()
+	tracked, last := s.untrackWindow(window)
+	s.mu.Unlock()
+	window.Close()
+	if tracked && last {
+		s.emitClose()
+	}
+}
+
+// withContext runs fn and returns its error, or returns ctx's error if ctx
+// is done first. In that case fn is left to finish in the background and its
+// result is discarded; fn should check ctx before acting, as `openURLAt`
+// does, so that it does not act after the caller has given up.
+func withContext(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// windowOptions returns the options for a new `wails3` fallback help window
+// showing url: `Options.WindowOptions` merged over the window defaults, the
+// position set by `Options.WindowX`, `Options.WindowY`, and
+// `Options.CenterOnShow`, the Escape key binding added by
+// `Options.CloseOnEscape`, the icon set by `Options.WindowIcon`, and the
+// maximized state set by `Options.Accessible`.
+func (s *Service) windowOptions(url string) application.WebviewWindowOptions {
+	options := s.opts.WindowOptions
+	options.Name = s.windowName()
+	options.URL = url
+	if s.opts.CloseOnEscape {
+		bindings := make(map[string]func(application.Window), len(options.KeyBindings)+1)
+		maps.Copy(bindings, options.KeyBindings)
+		bindings["escape"] = func(window application.Window) {
+			if window, ok := window.(helpWindow); ok {
+				s.closeWindow(window)
+			}
+		}
+		options.KeyBindings = bindings
+	}
+	if options.Title == "" {
+		options.Title = s.opts.WindowTitle
+	}
+	if options.Width == 0 {
+		options.Width = s.opts.WindowWidth
+	}
+	if options.Height == 0 {
+		options.Height = s.opts.WindowHeight
+	}
+	if options.Linux.Icon == nil {
+		options.Linux.Icon = s.opts.WindowIcon
+	}
+	if s.opts.Accessible && options.StartState == application.WindowStateNormal {
+		options.StartState = application.WindowStateMaximised
+	}
+	if options.X != 0 || options.Y != 0 || options.InitialPosition == application.WindowXY {
+		return options
+	}
+	switch {
+	case s.opts.CenterOnShow:
+		if x, y, ok := centerOnMain(s.windowFactory(), options.Name, options.Width, options.Height); ok {
+			options.InitialPosition = application.WindowXY
+			options.X, options.Y = x, y
+		} else {
+			options.InitialPosition = application.WindowCentered
+		}
+	case s.opts.WindowX != 0 || s.opts.WindowY != 0:
+		options.InitialPosition = application.WindowXY
+		options.X, options.Y = s.opts.WindowX, s.opts.WindowY
+	}
+	return options
+}
+
+// centerOnMain returns the position that centers the help window, named
+// name, at the given size over the current window of windows, and reports
+// whether there is such a window other than the help window.
+func centerOnMain(windows windowFactory, name string, width, height int) (x, y int, ok bool) {
+	if windows == nil {
+		return 0, 0, false
+	}
+	main := windows.Current()
+	if main == nil || main.Name() == name {
+		return 0, 0, false
+	}
+	bounds := main.Bounds()
+	return bounds.X + (bounds.Width-width)/2, bounds.Y + (bounds.Height-height)/2, true
+}
+
+// centerWindow moves window to the center of the current window of windows,
+// or of the screen if there is none.
+func centerWindow(windows windowFactory, window helpWindow) {
+	width, height := window.Size()
+	if x, y, ok := centerOnMain(windows, window.Name(), width, height); ok {
+		window.SetPosition(x, y)
+		return
+	}
+	window.Center()
+}
 
-	msg := map[string]any{
-		"action": "display.open_window",
-		"name":   "help",
-		"options": map[string]any{
-			"Title":  "Help",
-			"Width":  800,
-			"Height": 600,
-			"URL":    url,
-		},
+// windowName returns the name of the help window: "help", prefixed with
+// `Options.WindowNamePrefix` if set.
+func (s *Service) windowName() string {
+	if s.opts.WindowNamePrefix == "" {
+		return "help"
 	}
-	return s.core.ACTION(msg)
+	return s.opts.WindowNamePrefix + "-help"
 }
 
 // Ensure Service implements the Help interface.
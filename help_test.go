@@ -4,9 +4,16 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wailsapp/wails/v3/pkg/application"
+	"github.com/wailsapp/wails/v3/pkg/events"
 )
 
 // MockLogger is a mock implementation of the Logger interface.
@@ -42,11 +49,50 @@ func (m *MockCore) ACTION(msg map[string]any) error {
 func (m *MockCore) App() App { return m.app }
 
 // MockDisplay is a mock implementation of the Display interface.
-type MockDisplay struct{}
+type MockDisplay struct {
+	OpenCalled   bool
+	HideCalled   bool
+	CloseCalled  bool
+	ReloadCalled bool
+	WindowName   string
+	Options      map[string]any
+	Err          error
+}
+
+func (m *MockDisplay) OpenWindow(name string, options map[string]any) error {
+	m.OpenCalled = true
+	m.WindowName = name
+	m.Options = options
+	return m.Err
+}
+
+func (m *MockDisplay) HideWindow(name string) error {
+	m.HideCalled = true
+	m.WindowName = name
+	return m.Err
+}
+
+func (m *MockDisplay) CloseWindow(name string) error {
+	m.CloseCalled = true
+	m.WindowName = name
+	return m.Err
+}
+
+func (m *MockDisplay) ReloadWindow(name string) error {
+	m.ReloadCalled = true
+	m.WindowName = name
+	return m.Err
+}
 
 //go:embed all:public/*
 var testAssets embed.FS
 
+// testDocs is a small documentation set whose headings provide the anchors
+// used throughout the tests.
+var testDocs = NewMemorySource(map[string]string{
+	"index.md": "# Test Anchor\n\n## Good Anchor\n\n## Any Anchor\n\n## Getting Started\n",
+})
+
 func setupService(t *testing.T, opts Options) (*Service, *MockCore, *MockDisplay) {
 	s, err := New(opts)
 	assert.NoError(t, err)
@@ -74,62 +120,420 @@ func TestNew_WithAssets(t *testing.T) {
 	assert.Equal(t, testAssets, s.assets)
 }
 
+func TestNew_WindowDefaults(t *testing.T) {
+	s, err := New(Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Help", s.opts.WindowTitle)
+	assert.Equal(t, 800, s.opts.WindowWidth)
+	assert.Equal(t, 600, s.opts.WindowHeight)
+}
+
 func TestServiceStartup(t *testing.T) {
-	s, _, _ := setupService(t, Options{})
+	s, _, _ := setupService(t, Options{Assets: testDocs})
 	err := s.ServiceStartup(context.Background())
 	assert.NoError(t, err)
 }
 
+func TestServiceStartup_InvalidSource(t *testing.T) {
+	s, _, _ := setupService(t, Options{Source: t.TempDir()})
+	err := s.ServiceStartup(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "contains no documentation files")
+}
+
 func TestShow(t *testing.T) {
-	s, mockCore, _ := setupService(t, Options{})
+	s, _, mockDisplay := setupService(t, Options{})
 
 	err := s.Show()
 	assert.NoError(t, err)
-	assert.True(t, mockCore.ActionCalled)
-
-	msg := mockCore.ActionMsg
-	assert.Equal(t, "display.open_window", msg["action"])
-	assert.Equal(t, "help", msg["name"])
+	assert.True(t, mockDisplay.OpenCalled)
+	assert.Equal(t, "help", mockDisplay.WindowName)
 }
 
 func TestShowAt(t *testing.T) {
-	s, mockCore, _ := setupService(t, Options{})
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
 
 	err := s.ShowAt("test-anchor")
 	assert.NoError(t, err)
-	assert.True(t, mockCore.ActionCalled)
+	assert.True(t, mockDisplay.OpenCalled)
+	assert.Equal(t, "help", mockDisplay.WindowName)
+	assert.Equal(t, "/#test-anchor", mockDisplay.Options["URL"])
+}
+
+func TestShow_ReusesTrackedWindow(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+
+	assert.NoError(t, s.Show())
+	assert.NoError(t, s.ShowAt("test-anchor"))
+	created := windows.windows()
+	if !assert.Len(t, created, 1) {
+		return
+	}
+	url, shown, _ := created[0].state()
+	assert.Equal(t, "/#test-anchor", url)
+	assert.True(t, shown)
+	s.mu.Lock()
+	assert.Equal(t, created[0], s.window)
+	s.mu.Unlock()
+}
+
+func TestShow_ClearsClosedWindow(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+	closed := 0
+	s.OnClose(func() { closed++ })
+
+	assert.NoError(t, s.Show())
+	// The user closes the window.
+	windows.windows()[0].Close()
+	s.mu.Lock()
+	assert.Nil(t, s.window)
+	assert.Empty(t, s.windows)
+	s.mu.Unlock()
+	assert.Equal(t, 1, closed)
+
+	// Showing help again opens a new window.
+	assert.NoError(t, s.Show())
+	assert.Len(t, windows.windows(), 2)
+	s.mu.Lock()
+	assert.Equal(t, windows.windows()[1], s.window)
+	s.mu.Unlock()
+}
+
+func TestShow_CustomWindowOptions(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{
+		Assets:       testDocs,
+		WindowTitle:  "Docs",
+		WindowWidth:  1200,
+		WindowHeight: 900,
+	})
+
+	err := s.Show()
+	assert.NoError(t, err)
+
+	expectedOpts := map[string]any{
+		"Title":  "Docs",
+		"Width":  1200,
+		"Height": 900,
+	}
+	assert.Equal(t, expectedOpts, mockDisplay.Options)
+
+	err = s.ShowAt("test-anchor")
+	assert.NoError(t, err)
+
+	expectedOpts["URL"] = "/#test-anchor"
+	assert.Equal(t, expectedOpts, mockDisplay.Options)
+}
+
+func TestWindowOptions(t *testing.T) {
+	s, err := New(Options{WindowTitle: "Docs"})
+	assert.NoError(t, err)
+
+	opts := s.windowOptions("/#test-anchor")
+	assert.Equal(t, application.WebviewWindowOptions{
+		Name:   "help",
+		Title:  "Docs",
+		Width:  800,
+		Height: 600,
+		URL:    "/#test-anchor",
+	}, opts)
+}
+
+func TestWindowOptions_Position(t *testing.T) {
+	s, err := New(Options{WindowX: 100, WindowY: 50})
+	assert.NoError(t, err)
+	opts := s.windowOptions("/")
+	assert.Equal(t, application.WindowXY, opts.InitialPosition)
+	assert.Equal(t, 100, opts.X)
+	assert.Equal(t, 50, opts.Y)
+
+	// Without a main window, CenterOnShow centers on the screen.
+	s, err = New(Options{WindowX: 100, WindowY: 50, CenterOnShow: true})
+	assert.NoError(t, err)
+	opts = s.windowOptions("/")
+	assert.Equal(t, application.WindowCentered, opts.InitialPosition)
+	assert.Zero(t, opts.X)
+	assert.Zero(t, opts.Y)
+
+	// A position in WindowOptions wins.
+	s, err = New(Options{
+		WindowX:       100,
+		CenterOnShow:  true,
+		WindowOptions: application.WebviewWindowOptions{X: 5, Y: 6},
+	})
+	assert.NoError(t, err)
+	opts = s.windowOptions("/")
+	assert.Equal(t, 5, opts.X)
+	assert.Equal(t, 6, opts.Y)
+}
+
+func TestWindowOptions_CloseOnEscape(t *testing.T) {
+	bindings := map[string]func(application.Window){"f1": func(application.Window) {}}
+	s, err := New(Options{WindowOptions: application.WebviewWindowOptions{KeyBindings: bindings}})
+	assert.NoError(t, err)
+	assert.NotContains(t, s.windowOptions("/").KeyBindings, "escape")
+
+	s, err = New(Options{
+		CloseOnEscape: true,
+		WindowOptions: application.WebviewWindowOptions{KeyBindings: bindings},
+	})
+	assert.NoError(t, err)
+	opts := s.windowOptions("/")
+	assert.Contains(t, opts.KeyBindings, "escape")
+	assert.Contains(t, opts.KeyBindings, "f1")
+	assert.NotContains(t, bindings, "escape")
+}
+
+func TestCloseOnBlur(t *testing.T) {
+	s, err := New(Options{Assets: testDocs, WindowReuse: WindowReusePerAnchor, CloseOnBlur: true})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+	closed := 0
+	s.OnClose(func() { closed++ })
+	_, err = s.Serve("")
+	assert.NoError(t, err)
+	defer s.Close()
+
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.NoError(t, s.ShowAt("test-anchor"))
+	created := windows.windows()
+	if !assert.Len(t, created, 2) {
+		return
+	}
+
+	// Only the window that lost focus is closed.
+	created[0].emit(events.Common.WindowLostFocus)
+	_, _, isClosed := created[0].state()
+	assert.True(t, isClosed)
+	_, shown, _ := created[1].state()
+	assert.True(t, shown)
+	s.mu.Lock()
+	assert.Len(t, s.windows, 1)
+	assert.NotEmpty(t, s.serveURL)
+	s.mu.Unlock()
+	assert.Equal(t, 0, closed)
+
+	created[1].emit(events.Common.WindowLostFocus)
+	assert.Equal(t, 1, closed)
+	s.mu.Lock()
+	assert.Nil(t, s.window)
+	assert.NotEmpty(t, s.serveURL)
+	s.mu.Unlock()
+}
+
+func TestWindowNamePrefix(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, WindowNamePrefix: "pluginA"})
+	var closed int
+	s.OnClose(func() { closed++ })
+
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.Equal(t, "pluginA-help", mockDisplay.WindowName)
+	assert.NoError(t, s.Hide())
+	assert.Equal(t, "pluginA-help", mockDisplay.WindowName)
+
+	// Another instance's window closing is not ours.
+	assert.NoError(t, s.HandleIPCEvents(map[string]any{"action": "display.window_closed", "name": "help"}))
+	assert.Equal(t, 0, closed)
+	assert.NoError(t, s.HandleIPCEvents(map[string]any{"action": "display.window_closed", "name": "pluginA-help"}))
+	assert.Equal(t, 1, closed)
+
+	assert.Equal(t, "pluginA-help", s.windowOptions("/").Name)
+	s, err := New(Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, "help", s.windowOptions("/").Name)
+}
+
+func TestWindowOptions_Icon(t *testing.T) {
+	s, err := New(Options{})
+	assert.NoError(t, err)
+	assert.Nil(t, s.windowOptions("/").Linux.Icon)
+
+	icon := []byte("\x89PNG")
+	s, err = New(Options{WindowIcon: icon})
+	assert.NoError(t, err)
+	assert.Equal(t, icon, s.windowOptions("/").Linux.Icon)
+
+	// An icon in WindowOptions wins.
+	own := []byte("own")
+	s, err = New(Options{WindowIcon: icon, WindowOptions: application.WebviewWindowOptions{
+		Linux: application.LinuxWindow{Icon: own},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, own, s.windowOptions("/").Linux.Icon)
+}
+
+func TestWindowReuse(t *testing.T) {
+	tests := map[string]int{
+		"":                   1,
+		WindowReuseSingle:    1,
+		WindowReusePerAnchor: 2,
+		WindowReuseAlwaysNew: 3,
+	}
+	for mode, want := range tests {
+		s, err := New(Options{Assets: testDocs, WindowReuse: mode})
+		assert.NoError(t, err)
+		windows := &fakeWindows{}
+		s.windowing = windows
+		closed := 0
+		s.OnClose(func() { closed++ })
+
+		assert.NoError(t, s.ShowAt("good-anchor"), mode)
+		assert.NoError(t, s.ShowAt("test-anchor"), mode)
+		assert.NoError(t, s.ShowAt("good-anchor"), mode)
+		created := windows.windows()
+		assert.Len(t, created, want, mode)
+		assert.Len(t, s.windows, want, mode)
+		url, shown, _ := created[0].state()
+		assert.True(t, shown, mode)
+		assert.Contains(t, url, "#good-anchor", mode)
+
+		assert.NoError(t, s.Close(), mode)
+		assert.Empty(t, s.windows, mode)
+		assert.Nil(t, s.window, mode)
+		assert.Equal(t, 1, closed, mode)
+		for _, window := range created {
+			_, _, isClosed := window.state()
+			assert.True(t, isClosed, mode)
+		}
+	}
+
+	_, err := New(Options{WindowReuse: "tabs"})
+	assert.Error(t, err)
+}
+
+func TestCloseAll(t *testing.T) {
+	s, err := New(Options{Assets: testDocs, WindowReuse: WindowReusePerAnchor})
+	assert.NoError(t, err)
+	assert.NoError(t, s.CloseAll())
+
+	windows := &fakeWindows{}
+	s.windowing = windows
+	closed := 0
+	s.OnClose(func() { closed++ })
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.NoError(t, s.ShowAt("test-anchor"))
+	assert.Len(t, windows.windows(), 2)
+
+	assert.NoError(t, s.CloseAll())
+	assert.Empty(t, s.windows)
+	assert.Nil(t, s.window)
+	assert.Equal(t, 1, closed)
+	for _, window := range windows.windows() {
+		_, _, isClosed := window.state()
+		assert.True(t, isClosed)
+	}
+}
+
+func TestCloseAll_DisplayError(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	assert.NoError(t, s.Show())
+	_, err := s.Serve("")
+	assert.NoError(t, err)
+
+	mockDisplay.Err = assert.AnError
+	err = s.CloseAll()
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.True(t, mockDisplay.CloseCalled)
+	assert.Empty(t, s.serveURL, "the server is stopped despite the error")
+}
+
+func TestShow_DisplayPosition(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, WindowX: 100, WindowY: 50})
+	assert.NoError(t, s.Show())
+	assert.Equal(t, 100, mockDisplay.Options["X"])
+	assert.Equal(t, 50, mockDisplay.Options["Y"])
+	assert.NotContains(t, mockDisplay.Options, "Center")
+
+	s, _, mockDisplay = setupService(t, Options{Assets: testDocs, WindowX: 100, CenterOnShow: true})
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.Equal(t, true, mockDisplay.Options["Center"])
+	assert.NotContains(t, mockDisplay.Options, "X")
+}
+
+func TestWindowOptions_Merged(t *testing.T) {
+	s, err := New(Options{
+		WindowTitle: "Docs",
+		WindowOptions: application.WebviewWindowOptions{
+			Name:          "ignored",
+			URL:           "/ignored",
+			Width:         400,
+			AlwaysOnTop:   true,
+			Frameless:     true,
+			DisableResize: true,
+		},
+	})
+	assert.NoError(t, err)
+
+	opts := s.windowOptions("/")
+	assert.Equal(t, "help", opts.Name)
+	assert.Equal(t, "/", opts.URL)
+	assert.Equal(t, "Docs", opts.Title)
+	assert.Equal(t, 400, opts.Width)
+	assert.Equal(t, 600, opts.Height)
+	assert.True(t, opts.AlwaysOnTop)
+	assert.True(t, opts.Frameless)
+	assert.True(t, opts.DisableResize)
+}
 
-	msg := mockCore.ActionMsg
-	assert.Equal(t, "display.open_window", msg["action"])
-	assert.Equal(t, "help", msg["name"])
+func TestShowAt_AnchorNotFound(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
 
-	opts, ok := msg["options"].(map[string]any)
-	assert.True(t, ok)
-	assert.Equal(t, "/#test-anchor", opts["URL"])
+	err := s.ShowAt("getting-startd")
+	assert.Error(t, err)
+	assert.Equal(t, `help: anchor "getting-startd" not found`, err.Error())
+	assert.False(t, mockDisplay.OpenCalled)
 }
 
 func TestShowAt_CustomSource(t *testing.T) {
-	s, mockCore, _ := setupService(t, Options{Source: "custom"})
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "index.md"), []byte("# Test Anchor\n"), 0o644)
+	assert.NoError(t, err)
+	s, _, mockDisplay := setupService(t, Options{Source: dir})
 
-	err := s.ShowAt("test-anchor")
+	err = s.ShowAt("test-anchor")
+	assert.NoError(t, err)
+	assert.True(t, mockDisplay.OpenCalled)
+	assert.Equal(t, "help", mockDisplay.WindowName)
+	assert.Equal(t, "/#test-anchor", mockDisplay.Options["URL"])
+}
+
+func TestReady(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
 	assert.NoError(t, err)
-	assert.True(t, mockCore.ActionCalled)
+	assert.False(t, s.Ready())
 
-	msg := mockCore.ActionMsg
-	assert.Equal(t, "display.open_window", msg["action"])
-	assert.Equal(t, "help", msg["name"])
+	s.Init(nil, &MockDisplay{})
+	assert.False(t, s.Ready())
+	s.Init(&MockCore{}, &MockDisplay{})
+	assert.True(t, s.Ready())
+
+	s.Init(nil, nil)
+	s.app = &application.App{}
+	assert.True(t, s.Ready())
 
-	opts, ok := msg["options"].(map[string]any)
-	assert.True(t, ok)
-	assert.Equal(t, "/#test-anchor", opts["URL"])
+	s, err = New(Options{Assets: testDocs, Target: TargetBrowser})
+	assert.NoError(t, err)
+	assert.True(t, s.Ready())
+}
+
+func TestReady_NoDocumentation(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: NewMemorySource(map[string]string{"logo.png": "png"})})
+	assert.True(t, s.Ready())
+	assert.NoError(t, s.Warm(context.Background()))
+	assert.False(t, s.Ready())
 }
 
 func TestServiceStartup_CoreNotInitialized(t *testing.T) {
 	s, _, _ := setupService(t, Options{})
 	s.core = nil
 	err := s.ServiceStartup(context.Background())
-	assert.Error(t, err)
-	assert.Equal(t, "core runtime not initialized", err.Error())
+	assert.ErrorIs(t, err, ErrCoreNotInitialized)
 }
 
 func ExampleNew() {
@@ -166,7 +570,7 @@ func ExampleService_Show() {
 
 func ExampleService_ShowAt() {
 	// Create a new service and initialize it with mock dependencies.
-	s, _ := New(Options{})
+	s, _ := New(Options{Assets: testDocs})
 	s.Init(&MockCore{}, &MockDisplay{})
 
 	// Call the ShowAt method. In a real application, this would open a help
@@ -181,64 +585,69 @@ func ExampleService_ShowAt() {
 }
 
 func TestGood_ShowAndShowAt_DispatchesCorrectPayload(t *testing.T) {
-	s, mockCore, _ := setupService(t, Options{})
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
 
 	// Test Show()
 	err := s.Show()
 	assert.NoError(t, err)
-	assert.True(t, mockCore.ActionCalled)
+	assert.True(t, mockDisplay.OpenCalled)
 
-	expectedMsgShow := map[string]any{
-		"action": "display.open_window",
-		"name":   "help",
-		"options": map[string]any{
-			"Title":  "Help",
-			"Width":  800,
-			"Height": 600,
-		},
+	expectedOptsShow := map[string]any{
+		"Title":  "Help",
+		"Width":  800,
+		"Height": 600,
 	}
-	assert.Equal(t, expectedMsgShow, mockCore.ActionMsg)
+	assert.Equal(t, "help", mockDisplay.WindowName)
+	assert.Equal(t, expectedOptsShow, mockDisplay.Options)
 
 	// Reset mock and test ShowAt()
-	mockCore.ActionCalled = false
-	mockCore.ActionMsg = nil
+	mockDisplay.OpenCalled = false
+	mockDisplay.Options = nil
 
 	err = s.ShowAt("good-anchor")
 	assert.NoError(t, err)
-	assert.True(t, mockCore.ActionCalled)
+	assert.True(t, mockDisplay.OpenCalled)
 
-	expectedMsgShowAt := map[string]any{
-		"action": "display.open_window",
-		"name":   "help",
-		"options": map[string]any{
-			"Title":  "Help",
-			"Width":  800,
-			"Height": 600,
-			"URL":    "/#good-anchor",
-		},
+	expectedOptsShowAt := map[string]any{
+		"Title":  "Help",
+		"Width":  800,
+		"Height": 600,
+		"URL":    "/#good-anchor",
 	}
-	assert.Equal(t, expectedMsgShowAt, mockCore.ActionMsg)
+	assert.Equal(t, "help", mockDisplay.WindowName)
+	assert.Equal(t, expectedOptsShowAt, mockDisplay.Options)
 }
 
 func TestBad_ShowAt_EmptyAnchor(t *testing.T) {
-	s, mockCore, _ := setupService(t, Options{})
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
 
-	err := s.ShowAt("")
+	for _, anchor := range []string{"", "   ", "#", "\t#"} {
+		err := s.ShowAt(anchor)
+		assert.EqualError(t, err, "help: anchor must not be empty", "anchor %q", anchor)
+	}
+	assert.False(t, mockDisplay.OpenCalled)
+	assert.Empty(t, s.CurrentAnchor())
+}
+
+func TestGood_ShowAt_HashPrefix(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	err := s.ShowAt("#good-anchor")
 	assert.NoError(t, err)
-	assert.True(t, mockCore.ActionCalled)
+	assert.Equal(t, "/#good-anchor", mockDisplay.Options["URL"])
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
 
-	msg := mockCore.ActionMsg
-	opts, ok := msg["options"].(map[string]any)
-	assert.True(t, ok)
-	assert.Equal(t, "/#", opts["URL"])
+	err = s.ShowAt(" ##test-anchor ")
+	assert.NoError(t, err)
+	assert.Equal(t, "/#test-anchor", mockDisplay.Options["URL"])
 }
 
-func TestUgly_ActionError_Propagates(t *testing.T) {
-	s, mockCore, _ := setupService(t, Options{})
+func TestUgly_DisplayError_Propagates(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
 
-	// Simulate an error from the core.ACTION method
+	// Simulate an error from the display service
 	expectedErr := assert.AnError
-	mockCore.ActionErr = expectedErr
+	mockDisplay.Err = expectedErr
 
 	// Test Show()
 	err := s.Show()
@@ -255,30 +664,328 @@ func TestShow_DisplayNotInitialized(t *testing.T) {
 	s, _, _ := setupService(t, Options{})
 	s.display = nil
 	err := s.Show()
-	assert.Error(t, err)
-	assert.Equal(t, "wails application not running", err.Error())
+	assert.ErrorIs(t, err, ErrDisplayNotInitialized)
+	assert.ErrorIs(t, err, ErrWailsNotRunning)
+}
+
+func TestHeadless(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs, Headless: true})
+	s.display = nil
+
+	assert.NoError(t, s.Show())
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.True(t, mockCore.app.(*MockApp).logger.(*MockLogger).InfoCalled)
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+	assert.Error(t, s.ShowAt("missing-anchor"))
+	assert.NoError(t, s.Hide())
+	assert.NoError(t, s.Close())
 }
 
 func TestShow_CoreNotInitialized(t *testing.T) {
 	s, _, _ := setupService(t, Options{})
 	s.core = nil
 	err := s.Show()
-	assert.Error(t, err)
-	assert.Equal(t, "core runtime not initialized", err.Error())
+	assert.ErrorIs(t, err, ErrCoreNotInitialized)
 }
 
 func TestShowAt_DisplayNotInitialized(t *testing.T) {
 	s, _, _ := setupService(t, Options{})
 	s.display = nil
 	err := s.ShowAt("some-anchor")
-	assert.Error(t, err)
-	assert.Equal(t, "wails application not running", err.Error())
+	assert.ErrorIs(t, err, ErrDisplayNotInitialized)
+	assert.ErrorIs(t, err, ErrWailsNotRunning)
+}
+
+func TestShow_DefaultAnchor(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, DefaultAnchor: "getting-started"})
+
+	assert.NoError(t, s.Show())
+	assert.Equal(t, "/#getting-started", mockDisplay.Options["URL"])
+	assert.Equal(t, "getting-started", s.CurrentAnchor())
+
+	s, _, _ = setupService(t, Options{Assets: testDocs, DefaultAnchor: "missing"})
+	assert.Error(t, s.Show())
+}
+
+func TestShowAndShowAt_BasePath(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, BasePath: "/help/"})
+
+	assert.NoError(t, s.Show())
+	assert.Equal(t, "/help/", mockDisplay.Options["URL"])
+
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.Equal(t, "/help/#good-anchor", mockDisplay.Options["URL"])
 }
 
 func TestShowAt_CoreNotInitialized(t *testing.T) {
 	s, _, _ := setupService(t, Options{})
 	s.core = nil
 	err := s.ShowAt("some-anchor")
+	assert.ErrorIs(t, err, ErrCoreNotInitialized)
+}
+
+func TestHide(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{})
+
+	err := s.Hide()
+	assert.NoError(t, err)
+	assert.True(t, mockDisplay.HideCalled)
+	assert.False(t, mockDisplay.CloseCalled)
+	assert.Equal(t, "help", mockDisplay.WindowName)
+}
+
+func TestClose(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{})
+
+	err := s.Close()
+	assert.NoError(t, err)
+	assert.True(t, mockDisplay.CloseCalled)
+	assert.False(t, mockDisplay.HideCalled)
+	assert.Equal(t, "help", mockDisplay.WindowName)
+}
+
+func TestHideAndClose_DisplayNotInitialized(t *testing.T) {
+	s, _, _ := setupService(t, Options{})
+	s.display = nil
+
+	err := s.Hide()
+	assert.ErrorIs(t, err, ErrDisplayNotInitialized)
+	assert.ErrorIs(t, err, ErrWailsNotRunning)
+
+	err = s.Close()
+	assert.ErrorIs(t, err, ErrDisplayNotInitialized)
+	assert.ErrorIs(t, err, ErrWailsNotRunning)
+}
+
+func TestHideAndClose_CoreNotInitialized(t *testing.T) {
+	s, _, _ := setupService(t, Options{})
+	s.core = nil
+
+	err := s.Hide()
+	assert.ErrorIs(t, err, ErrCoreNotInitialized)
+
+	err = s.Close()
+	assert.ErrorIs(t, err, ErrCoreNotInitialized)
+}
+
+func TestReload(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	// Nothing is open yet.
+	assert.NoError(t, s.Reload())
+	assert.False(t, mockDisplay.ReloadCalled)
+
+	assert.NoError(t, s.Show())
+	assert.NoError(t, s.Reload())
+	assert.True(t, mockDisplay.ReloadCalled)
+	assert.Equal(t, "help", mockDisplay.WindowName)
+
+	mockDisplay.ReloadCalled = false
+	assert.NoError(t, s.Close())
+	assert.NoError(t, s.Reload())
+	assert.False(t, mockDisplay.ReloadCalled)
+}
+
+func TestReload_NoWindow(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	s.display = nil
+	assert.NoError(t, s.Reload())
+}
+
+// slowDisplay is a Display whose OpenWindow blocks until release is closed,
+// counting the calls in opens.
+type slowDisplay struct {
+	MockDisplay
+	release chan struct{}
+	opens   atomic.Int32
+}
+
+func (d *slowDisplay) OpenWindow(name string, options map[string]any) error {
+	d.opens.Add(1)
+	<-d.release
+	return nil
+}
+
+func TestShowContext(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	err := s.ShowContext(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, mockDisplay.OpenCalled)
+
+	err = s.ShowAtContext(context.Background(), "test-anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, "/#test-anchor", mockDisplay.Options["URL"])
+	assert.Equal(t, "test-anchor", s.CurrentAnchor())
+}
+
+func TestShowContext_Cancelled(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.ShowContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	err = s.ShowAtContext(ctx, "test-anchor")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, mockDisplay.OpenCalled)
+	assert.Empty(t, s.CurrentAnchor())
+}
+
+func TestShowContext_Deadline(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs})
+	display := &slowDisplay{release: make(chan struct{})}
+	defer close(display.release)
+	s.Init(mockCore, display)
+
+	var shown []string
+	s.OnShow(func(anchor string) { shown = append(shown, anchor) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := s.ShowAtContext(ctx, "test-anchor")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, s.CurrentAnchor())
+	assert.Empty(t, shown)
+}
+
+func TestShowContext_CancelledWhileWaiting(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs})
+	display := &slowDisplay{release: make(chan struct{})}
+	s.Init(mockCore, display)
+
+	first := make(chan error, 1)
+	go func() { first <- s.ShowAt("good-anchor") }()
+	assert.Eventually(t, func() bool {
+		return display.opens.Load() == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// A navigation waiting behind the first gives up, and does not run
+	// once the first has finished.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.ShowAtContext(ctx, "test-anchor"), context.DeadlineExceeded)
+	close(display.release)
+	assert.NoError(t, <-first)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(1), display.opens.Load())
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+}
+
+func TestHasDisplayAndMode(t *testing.T) {
+	s, err := New(Options{})
+	assert.NoError(t, err)
+	assert.False(t, s.HasDisplay())
+	assert.Equal(t, ModeUninitialized, s.Mode())
+
+	s.Init(&MockCore{}, &MockDisplay{})
+	assert.True(t, s.HasDisplay())
+	assert.Equal(t, ModeDisplay, s.Mode())
+}
+
+// siteDocs is a multi-page documentation set, as used with mkdocs.
+var siteDocs = fstest.MapFS{
+	"index.md":          {Data: []byte("# Home\n")},
+	"installation.md":   {Data: []byte("# Installation\n\n## Requirements\n")},
+	"guide/index.html":  {Data: []byte(`<h1 id="guide">Guide</h1><h2 id="setup">Setup</h2>`)},
+	"guide/advanced.md": {Data: []byte("# Advanced\n")},
+}
+
+func TestShowPage(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: siteDocs})
+
+	err := s.ShowPage("installation.html", "requirements")
+	assert.NoError(t, err)
+	assert.Equal(t, "/installation.html#requirements", mockDisplay.Options["URL"])
+	assert.Equal(t, "installation.html#requirements", s.CurrentAnchor())
+
+	err = s.ShowPage("/guide/", "#setup")
+	assert.NoError(t, err)
+	assert.Equal(t, "/guide/#setup", mockDisplay.Options["URL"])
+
+	// A markdown page opens at the URL its rendered page is served at.
+	err = s.ShowPage("installation.md", "requirements")
+	assert.NoError(t, err)
+	assert.Equal(t, "/installation.html#requirements", mockDisplay.Options["URL"])
+}
+
+func TestShowPage_Errors(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: siteDocs})
+
+	err := s.ShowPage("missing.html", "requirements")
+	assert.EqualError(t, err, `help: page "missing.html" not found: file does not exist`)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// The anchor must be defined on the page itself, not just anywhere.
+	err = s.ShowPage("index.md", "requirements")
+	assert.EqualError(t, err, `help: anchor "index.md#requirements" not found`)
+
+	err = s.ShowPage("", "requirements")
+	assert.EqualError(t, err, "help: page must not be empty")
+	err = s.ShowPage("installation.md", " ")
+	assert.EqualError(t, err, "help: anchor must not be empty")
+}
+
+func TestShowAt_PageAndAnchor(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: siteDocs})
+
+	err := s.ShowAt("installation.html#requirements")
+	assert.NoError(t, err)
+	assert.Equal(t, "/installation.html#requirements", mockDisplay.Options["URL"])
+
+	err = s.ShowAt("guide/advanced#advanced")
+	assert.NoError(t, err)
+	assert.Equal(t, "/guide/advanced.html#advanced", mockDisplay.Options["URL"])
+
+	// A bare anchor opens the page that defines it.
+	err = s.ShowAt("requirements")
+	assert.NoError(t, err)
+	assert.Equal(t, "/installation.html#requirements", mockDisplay.Options["URL"])
+
+	err = s.ShowAt("installation.html#")
+	assert.EqualError(t, err, "help: anchor must not be empty")
+}
+
+func TestShowWindow(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	window, err := s.ShowWindow()
+	assert.NoError(t, err)
+	assert.Nil(t, window)
+	assert.True(t, mockDisplay.OpenCalled)
+}
+
+func TestShowWindow_Error(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+
+	// Without a display or a running wails3 application there is nothing to
+	// show the window with.
+	window, err := s.ShowWindow()
 	assert.Error(t, err)
-	assert.Equal(t, "core runtime not initialized", err.Error())
+	assert.Nil(t, window)
+}
+
+// openOnlyDisplay overrides only OpenWindow of NopDisplay.
+type openOnlyDisplay struct {
+	NopDisplay
+	url string
+}
+
+func (d *openOnlyDisplay) OpenWindow(name string, options map[string]any) error {
+	d.url, _ = options["URL"].(string)
+	return nil
+}
+
+func TestNopDisplay(t *testing.T) {
+	var _ Display = NopDisplay{}
+
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs})
+	display := &openOnlyDisplay{}
+	s.Init(mockCore, display)
+
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.Equal(t, "/#good-anchor", display.url)
+	assert.NoError(t, s.Hide())
+	assert.NoError(t, s.Close())
 }
@@ -0,0 +1,70 @@
+package help
+
+import (
+	"context"
+	"fmt"
+)
+
+// Back re-opens the help window at the anchor visited before the current one
+// with `ShowAt`, like a browser's back button. It returns an error if there
+// is no earlier anchor in the navigation history.
+func (s *Service) Back() error {
+	return s.step(-1)
+}
+
+// Forward re-opens the help window at the anchor that `Back` last moved away
+// from, like a browser's forward button. It returns an error if there is no
+// later anchor in the navigation history.
+func (s *Service) Forward() error {
+	return s.step(1)
+}
+
+// CurrentAnchor returns the anchor the help window was last navigated to with
+// `ShowAt`, `Back`, or `Forward`, or an empty string if there is none.
+func (s *Service) CurrentAnchor() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.historyPos < 0 {
+		return ""
+	}
+	return s.history[s.historyPos]
+}
+
+// pushHistory records anchor as the current navigation history entry,
+// discarding any entries that `Forward` could have returned to.
+func (s *Service) pushHistory(anchor string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = append(s.history[:s.historyPos+1], anchor)
+	s.historyPos++
+}
+
+// step moves delta entries through the navigation history and opens the help
+// window at the anchor found there.
+func (s *Service) step(delta int) error {
+	s.mu.Lock()
+	pos := s.historyPos + delta
+	if pos < 0 || pos >= len(s.history) {
+		s.mu.Unlock()
+		if delta < 0 {
+			return fmt.Errorf("help: no previous section in history")
+		}
+		return fmt.Errorf("help: no next section in history")
+	}
+	anchor := s.history[pos]
+	s.mu.Unlock()
+
+	if err := s.openAt(context.Background(), anchor); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.historyPos = pos
+	s.mu.Unlock()
+	if delta < 0 {
+		s.emitShow("back", anchor)
+	} else {
+		s.emitShow("forward", anchor)
+	}
+	return nil
+}
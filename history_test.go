@@ -0,0 +1,66 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory_BackAndForward(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	assert.Equal(t, "", s.CurrentAnchor())
+
+	assert.NoError(t, s.ShowAt("test-anchor"))
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.NoError(t, s.ShowAt("any-anchor"))
+	assert.Equal(t, "any-anchor", s.CurrentAnchor())
+
+	assert.NoError(t, s.Back())
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+	opts := mockDisplay.Options
+	assert.Equal(t, "/#good-anchor", opts["URL"])
+
+	assert.NoError(t, s.Back())
+	assert.Equal(t, "test-anchor", s.CurrentAnchor())
+
+	assert.NoError(t, s.Forward())
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+	opts = mockDisplay.Options
+	assert.Equal(t, "/#good-anchor", opts["URL"])
+}
+
+func TestHistory_ShowAtTruncatesForward(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	assert.NoError(t, s.ShowAt("test-anchor"))
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.NoError(t, s.Back())
+	assert.NoError(t, s.ShowAt("getting-started"))
+
+	err := s.Forward()
+	assert.Error(t, err)
+	assert.Equal(t, "help: no next section in history", err.Error())
+
+	assert.NoError(t, s.Back())
+	assert.Equal(t, "test-anchor", s.CurrentAnchor())
+}
+
+func TestHistory_Empty(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	err := s.Back()
+	assert.Error(t, err)
+	assert.Equal(t, "help: no previous section in history", err.Error())
+
+	err = s.Forward()
+	assert.Error(t, err)
+	assert.False(t, mockDisplay.OpenCalled)
+}
+
+func TestHistory_FailedShowAtNotRecorded(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	assert.NoError(t, s.ShowAt("test-anchor"))
+	assert.Error(t, s.ShowAt("missing-anchor"))
+	assert.Equal(t, "test-anchor", s.CurrentAnchor())
+}
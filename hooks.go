@@ -0,0 +1,72 @@
+package help
+
+// OnShow registers fn to be called whenever the help window is shown by
+// `Show`, `ShowAt`, `Back`, or `Forward`. fn receives the anchor that was
+// opened, or an empty string for the documentation root. Handlers are called
+// in registration order, after the window has been opened.
+//
+// Example:
+//
+//	helpService.OnShow(func(anchor string) {
+//		player.Pause()
+//	})
+func (s *Service) OnShow(fn func(anchor string)) {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.showHandlers = append(s.showHandlers, fn)
+}
+
+// OnClose registers fn to be called whenever the help window is closed,
+// either by `Close` or, in the `wails3` fallback, by the user. Handlers are
+// called in registration order.
+//
+// Example:
+//
+//	helpService.OnClose(func() {
+//		player.Resume()
+//	})
+func (s *Service) OnClose(fn func()) {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.closeHandlers = append(s.closeHandlers, fn)
+}
+
+// emitShow logs a navigation to anchor, made by action, records it in
+// `Options.MetricsFile`, restarts the `Options.AutoCloseAfter` timer, and
+// calls the registered OnShow handlers with anchor. The log entry carries
+// structured `action`, `anchor`, `mode`, and `window_name` fields.
+func (s *Service) emitShow(action, anchor string) {
+	s.logger().Info("help: shown",
+		"action", action,
+		"anchor", anchor,
+		"mode", s.Mode(),
+		"window_name", s.windowName(),
+	)
+	s.recordMetric(action, anchor)
+	s.resetAutoClose()
+	s.hooksMu.Lock()
+	handlers := append([]func(string){}, s.showHandlers...)
+	s.hooksMu.Unlock()
+	for _, fn := range handlers {
+		fn(anchor)
+	}
+}
+
+// emitClose ends any tour in progress and stops the
+// `Options.AutoCloseAfter` timer. It then releases the `ShowModal` calls
+// waiting for the window to close, and calls the registered OnClose
+// handlers.
+func (s *Service) emitClose() {
+	s.stopTour()
+	s.stopAutoClose()
+	s.hooksMu.Lock()
+	handlers := append([]func(){}, s.closeHandlers...)
+	for _, ch := range s.closeWaiters {
+		close(ch)
+	}
+	s.closeWaiters = nil
+	s.hooksMu.Unlock()
+	for _, fn := range handlers {
+		fn()
+	}
+}
@@ -0,0 +1,54 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnShow(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	var calls []string
+	s.OnShow(func(anchor string) { calls = append(calls, "first:"+anchor) })
+	s.OnShow(func(anchor string) { calls = append(calls, "second:"+anchor) })
+
+	assert.NoError(t, s.Show())
+	assert.NoError(t, s.ShowAt("test-anchor"))
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.NoError(t, s.Back())
+
+	assert.Equal(t, []string{
+		"first:", "second:",
+		"first:test-anchor", "second:test-anchor",
+		"first:good-anchor", "second:good-anchor",
+		"first:test-anchor", "second:test-anchor",
+	}, calls)
+}
+
+func TestOnShow_NotCalledOnError(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	mockDisplay.Err = assert.AnError
+
+	called := false
+	s.OnShow(func(string) { called = true })
+
+	assert.Error(t, s.Show())
+	assert.Error(t, s.ShowAt("missing-anchor"))
+	assert.False(t, called)
+}
+
+func TestOnClose(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{})
+
+	var calls []int
+	s.OnClose(func() { calls = append(calls, 1) })
+	s.OnClose(func() { calls = append(calls, 2) })
+
+	assert.NoError(t, s.Close())
+	assert.Equal(t, []int{1, 2}, calls)
+
+	mockDisplay.Err = assert.AnError
+	assert.Error(t, s.Close())
+	assert.Equal(t, []int{1, 2}, calls)
+}
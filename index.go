@@ -0,0 +1,135 @@
+package help
+
+import (
+	"context"
+	"io/fs"
+	"slices"
+	"sort"
+)
+
+// contentIndex is the parsed documentation that `Search`,
+// `TableOfContents`, and `Anchors` work from, built once by walking the
+// documentation assets.
+type contentIndex struct {
+	// paths lists the documentation files in lexical order.
+	paths []string
+	// sections holds the sections of each file, by path.
+	sections map[string][]section
+	// anchors lists every anchor ID, sorted and without duplicates.
+	anchors []string
+	// anchorPages holds the file that defines each anchor ID, by ID: the
+	// root index page if it defines the anchor, or else the first file in
+	// lexical order that does.
+	anchorPages map[string]string
+	// toc is the table of contents, with files ordered by their front
+	// matter weight and then by path.
+	toc []TOCEntry
+	// tags holds the front matter tags of each file that has any, by path.
+	tags map[string][]string
+}
+
+// Warm builds and caches the index of the documentation that `Search`,
+// `TableOfContents`, and `Anchors` use, so that the first of those calls
+// does not pay the cost of reading and parsing every page. It returns ctx's
+// error if ctx is done before the index is built. `ServiceStartup` warms the
+// index in the background. The index is rebuilt after `SetSource` or
+// `SetLocale`, or when `Watch` sees the documentation change.
+//
+// Example:
+//
+//	if err := helpService.Warm(ctx); err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) Warm(ctx context.Context) error {
+	_, err := s.contentIndex(ctx)
+	return err
+}
+
+// contentIndex returns the cached index of the current documentation,
+// building it first if needed.
+func (s *Service) contentIndex(ctx context.Context) (*contentIndex, error) {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	if s.index != nil {
+		return s.index, nil
+	}
+	index, err := buildIndex(ctx, s.currentAssets(), s.warnWalk)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.aboutTarget(); ok {
+		// The generated about page comes last, after the documentation.
+		index.toc = slices.Concat(
+			slices.DeleteFunc(slices.Clone(index.toc), func(e TOCEntry) bool { return e.Path == aboutPageName }),
+			slices.DeleteFunc(slices.Clone(index.toc), func(e TOCEntry) bool { return e.Path != aboutPageName }),
+		)
+	}
+	s.index = index
+	return index, nil
+}
+
+// invalidateIndex discards the cached index, so that it is rebuilt from the
+// current documentation on next use.
+func (s *Service) invalidateIndex() {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	s.index = nil
+}
+
+// buildIndex walks fsys and parses every documentation file in it. Symbolic
+// links skipped while walking it are reported to warn.
+func buildIndex(ctx context.Context, fsys fs.FS, warn func(msg string)) (*contentIndex, error) {
+	index := &contentIndex{
+		sections:    make(map[string][]section),
+		tags:        make(map[string][]string),
+		anchorPages: make(map[string]string),
+	}
+	ids := make(map[string][]string)
+	weights := make(map[string]float64)
+	err := walkDocs(fsys, warn, func(p string, data []byte) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		index.paths = append(index.paths, p)
+		if isMarkdown(p) {
+			if meta, _, err := parseFrontMatter(data); err == nil {
+				if weight, ok := pageWeight(meta); ok {
+					weights[p] = weight
+				}
+				if tags := pageTags(meta); len(tags) > 0 {
+					index.tags[p] = tags
+				}
+			}
+		}
+		index.sections[p] = fileSections(p, data)
+		ids[p] = fileAnchors(p, data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(index.paths)
+	for _, p := range slices.Concat(indexPageNames, index.paths) {
+		for _, id := range ids[p] {
+			if _, ok := index.anchorPages[id]; !ok {
+				index.anchorPages[id] = p
+				index.anchors = append(index.anchors, id)
+			}
+		}
+	}
+	sort.Strings(index.anchors)
+	order := append([]string(nil), index.paths...)
+	sort.SliceStable(order, func(i, j int) bool {
+		wi, iok := weights[order[i]]
+		wj, jok := weights[order[j]]
+		if iok != jok {
+			return iok
+		}
+		return wi < wj
+	})
+	for _, p := range order {
+		index.toc = append(index.toc, buildTOC(p, index.sections[p])...)
+	}
+	return index, nil
+}
@@ -0,0 +1,82 @@
+package help
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarm(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.md": {Data: []byte("# Welcome\n\nFirst version.\n")},
+	}
+	s, err := New(Options{Assets: docs})
+	assert.NoError(t, err)
+	assert.NoError(t, s.Warm(context.Background()))
+
+	// Later queries are answered from the cached index.
+	docs["index.md"] = &fstest.MapFile{Data: []byte("# Changed\n\nSecond version.\n")}
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"welcome"}, anchors)
+	results, err := s.Search("first")
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	toc, err := s.TableOfContents()
+	assert.NoError(t, err)
+	assert.Equal(t, "Welcome", toc[0].Title)
+
+	// Switching the source rebuilds it.
+	assert.NoError(t, s.SetSource(Options{Assets: docs}))
+	anchors, err = s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"changed"}, anchors)
+}
+
+func TestWarm_Cancelled(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, s.Warm(ctx), context.Canceled)
+	// A failed build is not cached.
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Contains(t, anchors, "good-anchor")
+}
+
+func TestWarm_SetLocale(t *testing.T) {
+	s, err := New(Options{Assets: localeDocs, Locale: "en"})
+	assert.NoError(t, err)
+	assert.NoError(t, s.Warm(context.Background()))
+
+	assert.NoError(t, s.SetLocale("de"))
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"willkommen"}, anchors)
+}
+
+func TestWarm_Watch(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "index.md")
+	assert.NoError(t, os.WriteFile(file, []byte("# Before\n"), 0o644))
+	s, err := New(Options{Source: dir})
+	assert.NoError(t, err)
+	assert.NoError(t, s.Warm(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = s.Watch(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		_ = os.WriteFile(file, []byte("# After\n"), 0o644)
+		anchors, err := s.Anchors()
+		return err == nil && len(anchors) == 1 && anchors[0] == "after"
+	}, 5*time.Second, 50*time.Millisecond)
+}
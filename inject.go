@@ -0,0 +1,134 @@
+package help
+
+import (
+	"bytes"
+	"html"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// headClosePattern matches the closing tag of an HTML head.
+	headClosePattern = regexp.MustCompile(`(?i)</head\s*>`)
+	// bodyClosePattern matches the closing tag of an HTML body.
+	bodyClosePattern = regexp.MustCompile(`(?i)</body\s*>`)
+	// bodyOpenPattern matches the opening tag of an HTML body.
+	bodyOpenPattern = regexp.MustCompile(`(?i)<body(\s[^>]*)?>`)
+	// schemeAttrPattern matches the color scheme attribute of an HTML tag.
+	schemeAttrPattern = regexp.MustCompile(`(?i)\sdata-md-color-scheme\s*=\s*("[^"]*"|'[^']*'|[^\s>]+)`)
+)
+
+// injectFS wraps the documentation assets served by `Serve`, expanding
+// every HTML file with the `TemplateData` of the options, adding their
+// `ExtraCSS` and `ExtraJS` to it, and setting its color scheme to scheme, if
+// any; see `colorScheme`. Other files are served unchanged.
+type injectFS struct {
+	fs.FS
+	css, js []string
+	data    map[string]any
+	scheme  string
+}
+
+// Open opens the named file, expanding it and injecting the extras if it is
+// an HTML file.
+func (f *injectFS) Open(name string) (fs.File, error) {
+	file, err := f.FS.Open(name)
+	if err != nil || !isHTML(name) {
+		return file, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		return f.FS.Open(name)
+	}
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(file); err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	page := injectExtras(expandTemplate(name, buf.Bytes(), f.data), f.css, f.js)
+	page = injectScheme(page, f.scheme)
+	return &httpFile{
+		Reader: bytes.NewReader(page),
+		info:   httpFileInfo{name: path.Base(name), size: int64(len(page)), modTime: info.ModTime()},
+	}, nil
+}
+
+// injectExtras adds a tag for each of css before the end of the head of page,
+// and a tag for each of js before the end of its body. If page has no head or
+// body, the tags are added at its start or end instead.
+func injectExtras(page []byte, css, js []string) []byte {
+	var styles, scripts strings.Builder
+	for _, c := range css {
+		if isAssetURL(c, ".css") {
+			styles.WriteString(`<link rel="stylesheet" href="` + html.EscapeString(c) + `">`)
+		} else {
+			styles.WriteString("<style>" + c + "</style>")
+		}
+	}
+	for _, j := range js {
+		if isAssetURL(j, ".js") {
+			scripts.WriteString(`<script src="` + html.EscapeString(j) + `"></script>`)
+		} else {
+			scripts.WriteString("<script>" + j + "</script>")
+		}
+	}
+
+	out := page
+	if styles.Len() > 0 {
+		if loc := headClosePattern.FindIndex(out); loc != nil {
+			out = splice(out, loc[0], styles.String())
+		} else {
+			out = append([]byte(styles.String()), out...)
+		}
+	}
+	if scripts.Len() > 0 {
+		if locs := bodyClosePattern.FindAllIndex(out, -1); locs != nil {
+			out = splice(out, locs[len(locs)-1][0], scripts.String())
+		} else {
+			out = append(out, scripts.String()...)
+		}
+	}
+	return out
+}
+
+// injectScheme sets the `data-md-color-scheme` attribute of the body of
+// page, which the mkdocs material theme reads, to scheme, replacing any the
+// page has. Pages without a body, and any page if scheme is empty, are
+// returned unchanged.
+func injectScheme(page []byte, scheme string) []byte {
+	if scheme == "" {
+		return page
+	}
+	loc := bodyOpenPattern.FindIndex(page)
+	if loc == nil {
+		return page
+	}
+	tag := schemeAttrPattern.ReplaceAllLiteral(page[loc[0]:loc[1]], nil)
+	tag = splice(tag, len("<body"), ` data-md-color-scheme="`+scheme+`"`)
+	out := make([]byte, 0, len(page)+len(tag))
+	out = append(out, page[:loc[0]]...)
+	out = append(out, tag...)
+	return append(out, page[loc[1]:]...)
+}
+
+// splice returns data with s inserted at index i.
+func splice(data []byte, i int, s string) []byte {
+	out := make([]byte, 0, len(data)+len(s))
+	out = append(out, data[:i]...)
+	out = append(out, s...)
+	return append(out, data[i:]...)
+}
+
+// isAssetURL reports whether extra refers to a file by URL, rather than
+// being inline content. A URL is absolute, root- or dot-relative, or a single
+// word ending in ext.
+func isAssetURL(extra, ext string) bool {
+	for _, prefix := range []string{"http://", "https://", "//", "/", "./", "../"} {
+		if strings.HasPrefix(extra, prefix) {
+			return true
+		}
+	}
+	return !strings.ContainsAny(extra, " \t\r\n{};()") && strings.HasSuffix(strings.ToLower(extra), ext)
+}
@@ -0,0 +1,67 @@
+package help
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectExtras(t *testing.T) {
+	page := []byte("<html><head><title>Help</title></head><body><p>Hi</p></BODY></html>")
+	out := injectExtras(page,
+		[]string{"/brand.css", "body { font-family: serif; }"},
+		[]string{"https://example.com/feedback.js", "console.log('hi');"},
+	)
+	assert.Equal(t, "<html><head><title>Help</title>"+
+		`<link rel="stylesheet" href="/brand.css"><style>body { font-family: serif; }</style>`+
+		"</head><body><p>Hi</p>"+
+		`<script src="https://example.com/feedback.js"></script><script>console.log('hi');</script>`+
+		"</BODY></html>", string(out))
+}
+
+func TestInjectExtras_Fragment(t *testing.T) {
+	out := injectExtras([]byte("<p>Hi</p>"), []string{"brand.css"}, []string{"widget.js"})
+	assert.Equal(t, `<link rel="stylesheet" href="brand.css"><p>Hi</p><script src="widget.js"></script>`, string(out))
+}
+
+func TestInjectFS(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.html": {Data: []byte("<head></head><body></body>")},
+		"index.md":   {Data: []byte("# Title\n")},
+	}
+	fsys := &injectFS{FS: docs, css: []string{"/brand.css"}}
+
+	data, err := fs.ReadFile(fsys, "index.html")
+	assert.NoError(t, err)
+	assert.Equal(t, `<head><link rel="stylesheet" href="/brand.css"></head><body></body>`, string(data))
+
+	info, err := fs.Stat(fsys, "index.html")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), info.Size())
+
+	data, err = fs.ReadFile(fsys, "index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Title\n", string(data))
+}
+
+func TestServe_Extras(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.html": {Data: []byte("<html><head></head><body>Docs</body></html>")},
+		"site.css":   {Data: []byte("body {}")},
+	}
+	s, _, _ := setupService(t, Options{
+		Assets:   docs,
+		ExtraCSS: []string{"/brand.css"},
+		ExtraJS:  []string{"/widget.js"},
+	})
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	page := get(t, url)
+	assert.Contains(t, page, `<link rel="stylesheet" href="/brand.css"></head>`)
+	assert.Contains(t, page, `<script src="/widget.js"></script></body>`)
+	assert.Equal(t, "body {}", get(t, url+"site.css"))
+}
@@ -0,0 +1,128 @@
+package help
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// actionPrefix prefixes the names of the IPC actions handled by the help
+// service.
+const actionPrefix = "help."
+
+// ActionRegistrar is implemented by a `Core` runtime that can deliver IPC
+// action messages to services. `Register` uses it to subscribe the help
+// service to its actions.
+type ActionRegistrar interface {
+	// RegisterAction adds handler to the handlers called for each action
+	// message dispatched through the runtime.
+	RegisterAction(handler func(msg map[string]any) error)
+}
+
+// HandleIPCEvents handles an IPC action message delivered by the core
+// runtime. The action is named by the message's "action" key; actions that do
+// not start with "help." belong to other services and are ignored. The help
+// service handles:
+//
+//   - "help.show": shows the help window, as `Show` does.
+//   - "help.navigate": shows the section named by the message's "anchor"
+//     key, as `ShowAt` does.
+//   - "help.hide": hides the help window, as `Hide` does.
+//   - "help.close": closes the help window, as `Close` does.
+//
+// Actions added with `RegisterHandler` are passed to their handler. Any
+// other "help." action is logged and returned as an error. The
+// "display.window_closed" action that the `Display` service sends when the
+// user closes one of its windows is handled too: when its "name" key is the
+// help window's, "help" unless `Options.WindowNamePrefix` is set, the
+// `OnClose` handlers run and `ShowModal` returns. Likewise, a
+// "display.window_ready" action for the help window, sent once its page
+// has loaded, makes `ShowAndWait` return.
+//
+// This lets the frontend open contextual help by emitting an action, without
+// the host wiring up each call.
+//
+// Example:
+//
+//	err := helpService.HandleIPCEvents(map[string]any{
+//		"action": "help.navigate",
+//		"anchor": "getting-started",
+//	})
+func (s *Service) HandleIPCEvents(msg map[string]any) error {
+	action, _ := msg["action"].(string)
+	switch action {
+	case "display.window_closed":
+		if name, _ := msg["name"].(string); name == s.windowName() {
+			s.windowClosed()
+		}
+		return nil
+	case "display.window_ready":
+		if name, _ := msg["name"].(string); name == s.windowName() {
+			s.emitReady()
+		}
+		return nil
+	}
+	if !strings.HasPrefix(action, actionPrefix) {
+		return nil
+	}
+	switch action {
+	case "help.show":
+		return s.Show()
+	case "help.navigate":
+		anchor, _ := msg["anchor"].(string)
+		return s.ShowAt(anchor)
+	case "help.hide":
+		return s.Hide()
+	case "help.close":
+		return s.Close()
+	}
+	s.hooksMu.Lock()
+	handler := s.actionHandlers[action]
+	s.hooksMu.Unlock()
+	if handler != nil {
+		return handler(msg)
+	}
+	err := fmt.Errorf("help: unknown action %q", action)
+	s.logger().Error(err.Error())
+	return err
+}
+
+// RegisterHandler adds an IPC action for the help frontend to call back into
+// the application with, such as to submit feedback or rate a page.
+// `HandleIPCEvents` passes each message for the action to fn and returns
+// its error. The action is scoped to the help service: it is named
+// "help.<action>", and a name given with the "help." prefix is used as it
+// is. It is an error to register an action twice, to replace one of the
+// built-in actions, or to register a nil fn.
+//
+// Example:
+//
+//	err := helpService.RegisterHandler("feedback", func(msg map[string]any) error {
+//		text, _ := msg["text"].(string)
+//		return feedback.Submit(text)
+//	})
+func (s *Service) RegisterHandler(action string, fn func(msg map[string]any) error) error {
+	if fn == nil {
+		return errors.New("help: nil action handler")
+	}
+	if !strings.HasPrefix(action, actionPrefix) {
+		action = actionPrefix + action
+	}
+	if action == actionPrefix {
+		return errors.New("help: empty action name")
+	}
+	switch action {
+	case "help.show", "help.navigate", "help.hide", "help.close":
+		return fmt.Errorf("help: action %q is built in", action)
+	}
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	if _, ok := s.actionHandlers[action]; ok {
+		return fmt.Errorf("help: action %q already registered", action)
+	}
+	if s.actionHandlers == nil {
+		s.actionHandlers = make(map[string]func(msg map[string]any) error)
+	}
+	s.actionHandlers[action] = fn
+	return nil
+}
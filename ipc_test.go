@@ -0,0 +1,169 @@
+package help
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// registrarCore is a Core that can register action handlers and open
+// windows, like a full core runtime.
+type registrarCore struct {
+	MockCore
+	MockDisplay
+	handlers []func(msg map[string]any) error
+}
+
+func (c *registrarCore) RegisterAction(handler func(msg map[string]any) error) {
+	c.handlers = append(c.handlers, handler)
+}
+
+func TestRegister(t *testing.T) {
+	c := &registrarCore{MockCore: MockCore{app: &MockApp{logger: &MockLogger{}}}}
+
+	h, err := Register(c)
+	assert.NoError(t, err)
+	assert.NotNil(t, h)
+	assert.Len(t, c.handlers, 1)
+
+	s := h.(*Service)
+	assert.True(t, s.HasDisplay())
+	err = h.Show()
+	assert.NoError(t, err)
+	assert.True(t, c.OpenCalled)
+
+	err = c.handlers[0](map[string]any{"action": "help.close"})
+	assert.NoError(t, err)
+	assert.True(t, c.CloseCalled)
+}
+
+func TestRegister_PlainCore(t *testing.T) {
+	h, err := Register(&MockCore{app: &MockApp{logger: &MockLogger{}}})
+	assert.NoError(t, err)
+	assert.False(t, h.(*Service).HasDisplay())
+}
+
+func TestRegister_NilCore(t *testing.T) {
+	_, err := Register(nil)
+	assert.ErrorIs(t, err, ErrCoreNotInitialized)
+}
+
+func TestRegisterWails(t *testing.T) {
+	h, err := RegisterWails(&application.App{})
+	assert.NoError(t, err)
+	s := h.(*Service)
+	assert.False(t, s.HasDisplay())
+	assert.Equal(t, ModeWails, s.Mode())
+
+	s.assets = testDocs
+	assert.NoError(t, s.ServiceStartup(context.Background()))
+}
+
+func TestWailsLifecycle(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	s.app = &application.App{}
+	var lifecycle any = &wailsLifecycle{s}
+	startup, ok := lifecycle.(application.ServiceStartup)
+	assert.True(t, ok)
+	_, ok = lifecycle.(application.ServiceShutdown)
+	assert.True(t, ok)
+
+	ctx := context.Background()
+	assert.NoError(t, startup.ServiceStartup(ctx, application.ServiceOptions{}))
+	s.mu.Lock()
+	assert.Equal(t, ctx, s.ctx)
+	s.mu.Unlock()
+}
+
+func TestRegisterWails_NilApp(t *testing.T) {
+	_, err := RegisterWails(nil)
+	assert.ErrorIs(t, err, ErrWailsNotRunning)
+}
+
+func TestHandleIPCEvents(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	err := s.HandleIPCEvents(map[string]any{"action": "help.hide"})
+	assert.NoError(t, err)
+	assert.True(t, mockDisplay.HideCalled)
+
+	err = s.HandleIPCEvents(map[string]any{"action": "help.close"})
+	assert.NoError(t, err)
+	assert.True(t, mockDisplay.CloseCalled)
+}
+
+func TestHandleIPCEvents_ShowAndNavigate(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	err := s.HandleIPCEvents(map[string]any{"action": "help.show"})
+	assert.NoError(t, err)
+	assert.True(t, mockDisplay.OpenCalled)
+	assert.NotContains(t, mockDisplay.Options, "URL")
+
+	err = s.HandleIPCEvents(map[string]any{"action": "help.navigate", "anchor": "good-anchor"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/#good-anchor", mockDisplay.Options["URL"])
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+
+	err = s.HandleIPCEvents(map[string]any{"action": "help.navigate"})
+	assert.EqualError(t, err, "help: anchor must not be empty")
+	err = s.HandleIPCEvents(map[string]any{"action": "help.navigate", "anchor": "missing"})
+	assert.Error(t, err)
+}
+
+func TestHandleIPCEvents_OtherServices(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs})
+
+	err := s.HandleIPCEvents(map[string]any{"action": "display.open_window"})
+	assert.NoError(t, err)
+	err = s.HandleIPCEvents(map[string]any{})
+	assert.NoError(t, err)
+	assert.False(t, mockCore.App().Logger().(*MockLogger).ErrorCalled)
+}
+
+func TestHandleIPCEvents_UnknownAction(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs})
+
+	err := s.HandleIPCEvents(map[string]any{"action": "help.fly"})
+	assert.EqualError(t, err, `help: unknown action "help.fly"`)
+	assert.True(t, mockCore.App().Logger().(*MockLogger).ErrorCalled)
+}
+
+func TestRegisterHandler(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	var got map[string]any
+	assert.NoError(t, s.RegisterHandler("feedback", func(msg map[string]any) error {
+		got = msg
+		return nil
+	}))
+	assert.NoError(t, s.RegisterHandler("help.rate", func(map[string]any) error {
+		return errors.New("rating failed")
+	}))
+
+	msg := map[string]any{"action": "help.feedback", "text": "Great docs"}
+	assert.NoError(t, s.HandleIPCEvents(msg))
+	assert.Equal(t, msg, got)
+	assert.EqualError(t, s.HandleIPCEvents(map[string]any{"action": "help.rate"}), "rating failed")
+
+	// Unknown actions are still an error.
+	err := s.HandleIPCEvents(map[string]any{"action": "help.missing"})
+	assert.EqualError(t, err, `help: unknown action "help.missing"`)
+}
+
+func TestRegisterHandler_Errors(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	noop := func(map[string]any) error { return nil }
+
+	assert.Error(t, s.RegisterHandler("feedback", nil))
+	assert.Error(t, s.RegisterHandler("", noop))
+	assert.Error(t, s.RegisterHandler("help.", noop))
+	assert.Error(t, s.RegisterHandler("close", noop))
+	assert.Error(t, s.RegisterHandler("help.navigate", noop))
+	assert.NoError(t, s.RegisterHandler("feedback", noop))
+	assert.Error(t, s.RegisterHandler("help.feedback", noop))
+}
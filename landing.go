@@ -0,0 +1,99 @@
+package help
+
+import (
+	"bytes"
+	"html/template"
+	"io/fs"
+	"net/http"
+)
+
+// indexPageNames are the files that make a landing page for a documentation
+// directory.
+var indexPageNames = []string{"index.html", "index.md"}
+
+// landingPage is the landing page `Serve` generates for documentation
+// without one of its own.
+var landingPage = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<main>
+<h1>{{.Title}}</h1>
+<ul>
+{{- range .Pages}}
+<li><a href="{{.Path}}">{{.Title}}</a></li>
+{{- end}}
+</ul>
+</main>
+</body>
+</html>
+`))
+
+// landingLink is a page listed on the generated landing page.
+type landingLink struct {
+	Title string
+	Path  string
+}
+
+// hasIndexPage reports whether fsys has a landing page at its root.
+func hasIndexPage(fsys fs.FS) bool {
+	for _, name := range indexPageNames {
+		if _, err := fs.Stat(fsys, name); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// landingLinks returns the pages to list on the generated landing page: the
+// pages of the table of contents in its order, titled by their first
+// heading, followed by any pages without headings, titled by their path.
+func (s *Service) landingLinks() ([]landingLink, error) {
+	toc, err := s.TableOfContents()
+	if err != nil {
+		return nil, err
+	}
+	pages, err := s.ListPages()
+	if err != nil {
+		return nil, err
+	}
+	var links []landingLink
+	seen := make(map[string]bool, len(pages))
+	for _, entry := range toc {
+		if !seen[entry.Path] {
+			seen[entry.Path] = true
+			links = append(links, landingLink{Title: entry.Title, Path: entry.Path})
+		}
+	}
+	for _, page := range pages {
+		if !seen[page] {
+			links = append(links, landingLink{Title: page, Path: page})
+		}
+	}
+	return links, nil
+}
+
+// serveLanding serves a generated landing page listing every page of the
+// documentation, for a source that has no index page of its own, with the
+// extra CSS and JavaScript of the options.
+func (s *Service) serveLanding(w http.ResponseWriter, css, js []string) {
+	links, err := s.landingLinks()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var buf bytes.Buffer
+	err = landingPage.Execute(&buf, struct {
+		Title string
+		Pages []landingLink
+	}{s.opts.WindowTitle, links})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(injectExtras(buf.Bytes(), css, js))
+}
@@ -0,0 +1,54 @@
+package help
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServe_LandingPage(t *testing.T) {
+	s, _, _ := setupService(t, Options{
+		Assets: NewMemorySource(map[string]string{
+			"guide.md":   "# Guide\n\n## Install\n",
+			"setup.md":   "---\nweight: 1\n---\n# Setup & Go\n",
+			"notes.html": "<p>No headings.</p>",
+		}),
+		ExtraCSS: []string{"/brand.css"},
+	})
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	page := get(t, url)
+	assert.Contains(t, page, "<title>Help</title>")
+	assert.Contains(t, page, `<li><a href="setup.md">Setup &amp; Go</a></li>
+<li><a href="guide.md">Guide</a></li>
+<li><a href="notes.html">notes.html</a></li>`)
+	assert.Contains(t, page, `<link rel="stylesheet" href="/brand.css">`)
+	assert.NotContains(t, page, "Install")
+
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	assert.NotEmpty(t, resp.Header.Get("Content-Security-Policy"))
+
+	// Pages are still served.
+	assert.Contains(t, get(t, url+"guide.md"), "## Install")
+}
+
+func TestServe_ExistingIndex(t *testing.T) {
+	s, _, _ := setupService(t, Options{
+		Assets: NewMemorySource(map[string]string{
+			"index.html": "<h1>Home</h1>",
+			"guide.md":   "# Guide\n",
+		}),
+	})
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	assert.Equal(t, "<h1>Home</h1>", get(t, url))
+}
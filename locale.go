@@ -0,0 +1,98 @@
+package help
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+)
+
+// SetLocale switches the documentation to the translation in the locale
+// directory of the source, such as "de" for `public/de/`, or of the doc set
+// in use; see `SetDocSet`. Content lookups and
+// the help window URL are resolved within that directory. If it does not
+// exist, the service falls back to `Options.DefaultLocale`, or to the root of
+// the source, and logs a warning. An empty locale selects the default. Open
+// help windows are reloaded in the new locale; see `reloadWindows`.
+//
+// Example:
+//
+//	err := helpService.SetLocale("de")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (s *Service) SetLocale(locale string) error {
+	if err := checkLocale(locale); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	previous := s.docURL()
+	s.opts.Locale = locale
+	s.locale = pickLocale(localize(s.assets, s.opts.DocSet), locale, s.opts.DefaultLocale)
+	s.mu.Unlock()
+	s.invalidateIndex()
+	s.warnLocale()
+	return s.reloadWindows(previous)
+}
+
+// checkLocale returns an error if locale is not a single directory name.
+func checkLocale(locale string) error {
+	if locale == "" || fs.ValidPath(locale) && locale != "." && !strings.Contains(locale, "/") {
+		return nil
+	}
+	return fmt.Errorf("help: invalid locale %q", locale)
+}
+
+// pickLocale returns the locale directory of root to show: locale if it
+// exists, otherwise fallback if that exists, otherwise "" for the root.
+func pickLocale(root fs.FS, locale, fallback string) string {
+	for _, l := range []string{locale, fallback} {
+		if l != "" && hasLocale(root, l) {
+			return l
+		}
+	}
+	return ""
+}
+
+// hasLocale reports whether root has a directory for locale. Directories on
+// a remote source cannot be checked and are assumed to exist.
+func hasLocale(root fs.FS, locale string) bool {
+	if _, ok := root.(*httpFS); ok {
+		return true
+	}
+	info, err := fs.Stat(root, locale)
+	return err == nil && info.IsDir()
+}
+
+// localize returns the part of root in the directory locale, which holds
+// the documentation for a locale or doc set, or root itself if locale is
+// empty.
+func localize(root fs.FS, locale string) fs.FS {
+	if locale == "" {
+		return root
+	}
+	if h, ok := root.(*httpFS); ok {
+		return &httpFS{base: h.base.ResolveReference(&url.URL{Path: locale + "/"}), client: h.client, cache: h.cache}
+	}
+	sub, err := fs.Sub(root, locale)
+	if err != nil {
+		return root
+	}
+	return sub
+}
+
+// warnLocale logs a warning through the application logger if the
+// requested locale is not available and a fallback is in use.
+func (s *Service) warnLocale() {
+	s.mu.Lock()
+	requested, actual := s.opts.Locale, s.locale
+	s.mu.Unlock()
+	if requested == "" || requested == actual {
+		return
+	}
+	fallback := "the documentation root"
+	if actual != "" {
+		fallback = fmt.Sprintf("locale %q", actual)
+	}
+	s.logger().Error(fmt.Sprintf("help: locale %q not found, using %s", requested, fallback))
+}
@@ -0,0 +1,125 @@
+package help
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var localeDocs = fstest.MapFS{
+	"en/index.md": {Data: []byte("# Welcome\n")},
+	"de/index.md": {Data: []byte("# Willkommen\n")},
+}
+
+func TestLocale(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: localeDocs, Locale: "de"})
+
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"willkommen"}, anchors)
+
+	err = s.Show()
+	assert.NoError(t, err)
+	assert.Equal(t, "/de/", mockDisplay.Options["URL"])
+
+	err = s.ShowAt("willkommen")
+	assert.NoError(t, err)
+	assert.Equal(t, "/de/#willkommen", mockDisplay.Options["URL"])
+
+	err = s.ShowAt("welcome")
+	assert.Error(t, err)
+}
+
+func TestLocale_DefaultLocale(t *testing.T) {
+	s, err := New(Options{Assets: localeDocs, DefaultLocale: "en"})
+	assert.NoError(t, err)
+
+	data, err := s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Welcome\n", string(data))
+}
+
+func TestSetLocale(t *testing.T) {
+	s, mockCore, mockDisplay := setupService(t, Options{Assets: localeDocs, DefaultLocale: "en"})
+	logger := mockCore.App().Logger().(*MockLogger)
+
+	err := s.SetLocale("de")
+	assert.NoError(t, err)
+	assert.False(t, logger.ErrorCalled)
+	err = s.ShowAt("willkommen")
+	assert.NoError(t, err)
+	assert.Equal(t, "/de/#willkommen", mockDisplay.Options["URL"])
+
+	// A missing locale falls back to the default, with a warning.
+	err = s.SetLocale("fr")
+	assert.NoError(t, err)
+	assert.True(t, logger.ErrorCalled)
+	err = s.ShowAt("welcome")
+	assert.NoError(t, err)
+	assert.Equal(t, "/en/#welcome", mockDisplay.Options["URL"])
+}
+
+func TestSetLocale_ReloadsWindows(t *testing.T) {
+	docs := NewMemorySource(map[string]string{
+		"en/index.md": "# Welcome\n\n## Setup\n",
+		"de/index.md": "# Willkommen\n\n## Setup\n",
+	})
+	s, err := New(Options{Assets: docs, Locale: "en", WindowReuse: WindowReusePerAnchor})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+	assert.NoError(t, s.ShowAt("welcome"))
+	assert.NoError(t, s.ShowAt("setup"))
+
+	assert.NoError(t, s.SetLocale("de"))
+	if assert.Len(t, windows.windows(), 2) {
+		first, _, _ := windows.windows()[0].state()
+		assert.Equal(t, "/de/#welcome", first)
+		second, _, _ := windows.windows()[1].state()
+		assert.Equal(t, "/de/#setup", second)
+	}
+}
+
+func TestSetLocale_ReloadsDisplay(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: localeDocs, Locale: "en"})
+	assert.NoError(t, s.Show())
+
+	mockDisplay.OpenCalled = false
+	assert.NoError(t, s.SetLocale("de"))
+	assert.True(t, mockDisplay.OpenCalled)
+	assert.Equal(t, "/de/", mockDisplay.Options["URL"])
+}
+
+func TestSetLocale_NoFallback(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	err := s.SetLocale("fr")
+	assert.NoError(t, err)
+	err = s.ShowAt("test-anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, "/#test-anchor", mockDisplay.Options["URL"])
+}
+
+func TestSetLocale_Invalid(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: localeDocs})
+
+	for _, locale := range []string{"../de", "de/at", "."} {
+		assert.Error(t, s.SetLocale(locale), locale)
+	}
+	_, err := New(Options{Locale: "../de"})
+	assert.Error(t, err)
+}
+
+func TestLocale_RemoteSource(t *testing.T) {
+	server := newDocsServer(t)
+	s, _, mockDisplay := setupService(t, Options{Source: server.URL, Locale: "docs"})
+
+	err := s.Show()
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/docs/", mockDisplay.Options["URL"])
+
+	data, err := s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Remote\n", string(data))
+}
@@ -0,0 +1,44 @@
+package help
+
+// SetLogger sets the logger the help service reports to, in place of
+// `Options.Logger` and the application logger of the core runtime. Passing
+// nil restores the default.
+//
+// Example:
+//
+//	helpService.SetLogger(slog.New(slog.NewJSONHandler(os.Stderr, nil)))
+func (s *Service) SetLogger(logger Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.log = logger
+}
+
+// logger returns the logger to report to: the one set with `SetLogger`,
+// `Options.Logger`, or the application logger of the core runtime,
+// whichever is available first. If none is, messages are discarded. It
+// never returns nil.
+func (s *Service) logger() Logger {
+	s.mu.Lock()
+	logger := s.log
+	if logger == nil {
+		logger = s.opts.Logger
+	}
+	s.mu.Unlock()
+	if logger != nil {
+		return logger
+	}
+	if s.core != nil {
+		if app := s.core.App(); app != nil {
+			if logger := app.Logger(); logger != nil {
+				return logger
+			}
+		}
+	}
+	return nopLogger{}
+}
+
+// nopLogger is a `Logger` that discards every message.
+type nopLogger struct{}
+
+func (nopLogger) Info(message string, args ...any)  {}
+func (nopLogger) Error(message string, args ...any) {}
@@ -0,0 +1,96 @@
+package help
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_NilApp(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	s.Init(&MockCore{}, &MockDisplay{})
+
+	assert.NotPanics(t, func() {
+		assert.NoError(t, s.ServiceStartup(context.Background()))
+	})
+	assert.Equal(t, nopLogger{}, s.logger())
+
+	s.Init(&MockCore{app: &MockApp{}}, &MockDisplay{})
+	assert.Equal(t, nopLogger{}, s.logger())
+}
+
+func TestOptionsLogger(t *testing.T) {
+	logger := &MockLogger{}
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs, Logger: logger})
+	appLogger := mockCore.App().Logger().(*MockLogger)
+
+	assert.NoError(t, s.ServiceStartup(context.Background()))
+	assert.True(t, logger.InfoCalled)
+	assert.False(t, appLogger.InfoCalled)
+
+	override := &MockLogger{}
+	s.SetLogger(override)
+	assert.Equal(t, override, s.logger())
+	s.SetLogger(nil)
+	assert.Equal(t, logger, s.logger())
+
+	s, err := NewWithOptions(WithAssets(testDocs), WithLogger(logger))
+	assert.NoError(t, err)
+	assert.Equal(t, logger, s.logger())
+}
+
+func TestSetLogger(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs})
+	appLogger := mockCore.App().Logger().(*MockLogger)
+	logger := &MockLogger{}
+	s.SetLogger(logger)
+
+	err := s.HandleIPCEvents(map[string]any{"action": "help.fly"})
+	assert.Error(t, err)
+	assert.True(t, logger.ErrorCalled)
+	assert.False(t, appLogger.ErrorCalled)
+
+	s.SetLogger(nil)
+	assert.Equal(t, appLogger, s.logger())
+}
+
+func TestSetLogger_WithoutCore(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	logger := &MockLogger{}
+	s.SetLogger(logger)
+
+	err = s.HandleIPCEvents(map[string]any{"action": "help.fly"})
+	assert.Error(t, err)
+	assert.True(t, logger.ErrorCalled)
+}
+
+// recordingLogger is a Logger that keeps the messages and arguments of its
+// info entries.
+type recordingLogger struct {
+	MockLogger
+	entries [][]any
+}
+
+func (l *recordingLogger) Info(message string, args ...any) {
+	l.entries = append(l.entries, append([]any{message}, args...))
+}
+
+func TestNavigationLogging(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	logger := &recordingLogger{}
+	s.SetLogger(logger)
+
+	assert.NoError(t, s.Show())
+	assert.NoError(t, s.ShowAt("#good-anchor"))
+	assert.NoError(t, s.ShowAt("test-anchor"))
+	assert.NoError(t, s.Back())
+	assert.Equal(t, [][]any{
+		{"help: shown", "action", "show", "anchor", "", "mode", ModeDisplay, "window_name", "help"},
+		{"help: shown", "action", "show_at", "anchor", "good-anchor", "mode", ModeDisplay, "window_name", "help"},
+		{"help: shown", "action", "show_at", "anchor", "test-anchor", "mode", ModeDisplay, "window_name", "help"},
+		{"help: shown", "action", "back", "anchor", "good-anchor", "mode", ModeDisplay, "window_name", "help"},
+	}, logger.entries)
+}
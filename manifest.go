@@ -0,0 +1,67 @@
+package help
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+)
+
+// Manifest is the machine-readable structure of the documentation produced
+// by `ExportManifest`.
+type Manifest struct {
+	// Pages lists the documentation files, sorted by path.
+	Pages []ManifestPage `json:"pages"`
+	// TOC is the table of contents, as returned by `TableOfContents`.
+	TOC []TOCEntry `json:"toc"`
+}
+
+// ManifestPage describes a single documentation file in a `Manifest`.
+type ManifestPage struct {
+	// Path is the path of the file, relative to the documentation root.
+	Path string `json:"path"`
+	// Anchors lists the anchors of the file's headings, sorted and without
+	// duplicates.
+	Anchors []string `json:"anchors"`
+}
+
+// ExportManifest returns a JSON dump of the structure of the documentation,
+// a `Manifest` with the list of pages, the anchors of each, and the table of
+// contents, for external tools such as a search index or a navigation
+// component of the application's own. The output is deterministic, with
+// pages and anchors sorted, so that it can be kept in version control and
+// diffed.
+//
+// Example:
+//
+//	data, err := helpService.ExportManifest()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = os.WriteFile("help-manifest.json", data, 0o644)
+func (s *Service) ExportManifest() ([]byte, error) {
+	index, err := s.contentIndex(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	manifest := Manifest{
+		Pages: make([]ManifestPage, 0, len(index.paths)),
+		TOC:   append([]TOCEntry{}, index.toc...),
+	}
+	for _, p := range index.paths {
+		anchors := []string{}
+		seen := make(map[string]bool)
+		for _, sec := range index.sections[p] {
+			if sec.level > 0 && !seen[sec.anchor] {
+				seen[sec.anchor] = true
+				anchors = append(anchors, sec.anchor)
+			}
+		}
+		sort.Strings(anchors)
+		manifest.Pages = append(manifest.Pages, ManifestPage{Path: p, Anchors: anchors})
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
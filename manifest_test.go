@@ -0,0 +1,34 @@
+package help
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportManifest(t *testing.T) {
+	docs := NewMemorySource(map[string]string{
+		"index.md":       "# Welcome\n\n## Setup\n\n## Billing\n",
+		"guide/faq.html": `<h1 id="faq">FAQ</h1><h2 id="refunds">Refunds</h2>`,
+		"logo.png":       "png",
+	})
+	s, err := New(Options{Assets: docs})
+	assert.NoError(t, err)
+
+	data, err := s.ExportManifest()
+	assert.NoError(t, err)
+	again, err := s.ExportManifest()
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(again))
+
+	var manifest Manifest
+	assert.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, []ManifestPage{
+		{Path: "guide/faq.html", Anchors: []string{"faq", "refunds"}},
+		{Path: "index.md", Anchors: []string{"billing", "setup", "welcome"}},
+	}, manifest.Pages)
+	toc, err := s.TableOfContents()
+	assert.NoError(t, err)
+	assert.Equal(t, toc, manifest.TOC)
+}
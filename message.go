@@ -0,0 +1,63 @@
+package help
+
+// OpenWindowMessage describes a window for a `Display` service to open: the
+// request that `Show` and `ShowAt` send through `Display.OpenWindow`. Build
+// one to drive a display of your own the same way, or to check what the
+// help service asks for in tests.
+type OpenWindowMessage struct {
+	// Name identifies the window; the help window is named "help", or
+	// as set by `Options.WindowNamePrefix`.
+	Name string
+	// Title, Width, and Height set the window's title and size.
+	Title  string
+	Width  int
+	Height int
+	// URL is the page to open. If empty, the display opens its default
+	// page, the documentation root.
+	URL string
+	// X and Y position the window on screen. If both are zero, the
+	// display chooses the position.
+	X int
+	Y int
+	// Center centers the window, in place of X and Y.
+	Center bool
+	// Maximised opens the window maximized.
+	Maximised bool
+	// Icon is the window icon, as PNG bytes. If empty, the display uses
+	// its default icon.
+	Icon []byte
+}
+
+// Map returns the options of m in the map form taken by
+// `Display.OpenWindow`, with the keys "Title", "Width", "Height", and those
+// of the optional fields that are set: "URL", "X" and "Y", "Center", and
+// "Maximised", and "Icon". The name is passed to OpenWindow separately.
+//
+// Example:
+//
+//	msg := help.OpenWindowMessage{Name: "help", Title: "Help", Width: 800, Height: 600}
+//	err := display.OpenWindow(msg.Name, msg.Map())
+func (m OpenWindowMessage) Map() map[string]any {
+	options := map[string]any{
+		"Title":  m.Title,
+		"Width":  m.Width,
+		"Height": m.Height,
+	}
+	if m.URL != "" {
+		options["URL"] = m.URL
+	}
+	switch {
+	case m.Center:
+		options["Center"] = true
+	case m.X != 0 || m.Y != 0:
+		options["X"] = m.X
+		options["Y"] = m.Y
+	}
+	if m.Maximised {
+		options["Maximised"] = true
+	}
+	if len(m.Icon) > 0 {
+		options["Icon"] = m.Icon
+	}
+	return options
+}
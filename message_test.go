@@ -0,0 +1,47 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenWindowMessage_Map(t *testing.T) {
+	msg := OpenWindowMessage{Name: "help", Title: "Help", Width: 800, Height: 600}
+	assert.Equal(t, map[string]any{"Title": "Help", "Width": 800, "Height": 600}, msg.Map())
+
+	msg.URL = "/#intro"
+	msg.X, msg.Y = 10, 20
+	msg.Maximised = true
+	assert.Equal(t, map[string]any{
+		"Title": "Help", "Width": 800, "Height": 600,
+		"URL": "/#intro", "X": 10, "Y": 20, "Maximised": true,
+	}, msg.Map())
+
+	msg.Center = true
+	assert.Equal(t, map[string]any{
+		"Title": "Help", "Width": 800, "Height": 600,
+		"URL": "/#intro", "Center": true, "Maximised": true,
+	}, msg.Map())
+}
+
+func TestShow_OpenWindowMessage(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, WindowTitle: "Manual", WindowX: 5, WindowY: 7})
+
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	want := OpenWindowMessage{Name: "help", Title: "Manual", Width: 800, Height: 600, URL: "/#good-anchor", X: 5, Y: 7}
+	assert.Equal(t, want.Name, mockDisplay.WindowName)
+	assert.Equal(t, want.Map(), mockDisplay.Options)
+
+	assert.NoError(t, s.Show())
+	want.URL = ""
+	assert.Equal(t, want.Map(), mockDisplay.Options)
+}
+
+func TestShow_OpenWindowMessageIcon(t *testing.T) {
+	icon := []byte("\x89PNG")
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, WindowIcon: icon})
+
+	assert.NoError(t, s.Show())
+	assert.Equal(t, icon, mockDisplay.Options["Icon"])
+}
@@ -0,0 +1,112 @@
+package help
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PageMeta returns the YAML front matter of the markdown file at path, the
+// block between "---" lines at the very start of the file, such as:
+//
+//	---
+//	title: Getting Started
+//	tags: [intro]
+//	weight: 10
+//	---
+//
+// A file without front matter, or an HTML file, has an empty map. The front
+// matter is not part of the page text: it is left out of `Anchors`,
+// `Search`, and `RenderPage`. A `weight` or `order` number in it places the
+// page in `TableOfContents`. Paths are handled as by `ReadPage`.
+//
+// Example:
+//
+//	meta, err := helpService.PageMeta("guide/setup.md")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if title, ok := meta["title"].(string); ok {
+//		fmt.Println(title)
+//	}
+func (s *Service) PageMeta(path string) (map[string]any, error) {
+	data, err := s.ReadPage(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isMarkdown(path) {
+		return map[string]any{}, nil
+	}
+	meta, _, err := parseFrontMatter(data)
+	if err != nil {
+		return nil, fmt.Errorf("help: front matter of %q: %w", path, err)
+	}
+	return meta, nil
+}
+
+// splitFrontMatter splits a markdown document into its front matter and
+// the body that follows it. A document without front matter has none and
+// is returned whole as the body.
+func splitFrontMatter(data []byte) (front, body []byte) {
+	rest, ok := cutLine(data, "---")
+	if !ok {
+		return nil, data
+	}
+	for i := 0; i < len(rest); {
+		end := bytes.IndexByte(rest[i:], '\n')
+		if end < 0 {
+			end = len(rest) - i
+		}
+		line := bytes.TrimRight(rest[i:i+end], "\r")
+		if string(line) == "---" || string(line) == "..." {
+			next := min(i+end+1, len(rest))
+			return rest[:i], rest[next:]
+		}
+		i += end + 1
+	}
+	return nil, data
+}
+
+// cutLine reports whether data begins with a line that is exactly line, and
+// returns what follows that line.
+func cutLine(data []byte, line string) ([]byte, bool) {
+	rest, ok := bytes.CutPrefix(data, []byte(line))
+	if !ok {
+		return data, false
+	}
+	rest = bytes.TrimPrefix(rest, []byte("\r"))
+	return bytes.CutPrefix(rest, []byte("\n"))
+}
+
+// parseFrontMatter parses the front matter of a markdown document and
+// returns it with the body that follows it. A document without front matter
+// has an empty map.
+func parseFrontMatter(data []byte) (map[string]any, []byte, error) {
+	front, body := splitFrontMatter(data)
+	meta := map[string]any{}
+	if len(bytes.TrimSpace(front)) == 0 {
+		return meta, body, nil
+	}
+	if err := yaml.Unmarshal(front, &meta); err != nil {
+		return nil, body, err
+	}
+	if meta == nil {
+		meta = map[string]any{}
+	}
+	return meta, body, nil
+}
+
+// pageWeight returns the `weight` or `order` number of front matter, and
+// reports whether it has one.
+func pageWeight(meta map[string]any) (float64, bool) {
+	for _, key := range []string{"weight", "order"} {
+		switch v := meta[key].(type) {
+		case int:
+			return float64(v), true
+		case float64:
+			return v, true
+		}
+	}
+	return 0, false
+}
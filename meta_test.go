@@ -0,0 +1,74 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageMeta(t *testing.T) {
+	s, err := New(Options{Assets: NewMemorySource(map[string]string{
+		"setup.md":  "---\ntitle: Setup Guide\ntags: [install, intro]\nweight: 10\n---\n# Setup\n",
+		"empty.md":  "---\n---\n# Empty\n",
+		"plain.md":  "# Plain\n\n---\n\nNot front matter.\n",
+		"page.html": "<h1>Page</h1>",
+		"broken.md": "---\ntitle: [unclosed\n---\n",
+	})})
+	assert.NoError(t, err)
+
+	meta, err := s.PageMeta("setup.md")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"title":  "Setup Guide",
+		"tags":   []any{"install", "intro"},
+		"weight": 10,
+	}, meta)
+
+	for _, p := range []string{"empty.md", "plain.md", "page.html"} {
+		meta, err = s.PageMeta(p)
+		assert.NoError(t, err, p)
+		assert.Equal(t, map[string]any{}, meta, p)
+	}
+
+	_, err = s.PageMeta("broken.md")
+	assert.Error(t, err)
+	_, err = s.PageMeta("missing.md")
+	assert.Error(t, err)
+}
+
+func TestFrontMatter_NotContent(t *testing.T) {
+	s, err := New(Options{Assets: NewMemorySource(map[string]string{
+		"index.md": "---\n# a yaml comment\ntitle: Hidden Title\n---\n# Welcome\n",
+	})})
+	assert.NoError(t, err)
+
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"welcome"}, anchors)
+
+	results, err := s.Search("hidden")
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	page, err := s.RenderPage("index.md")
+	assert.NoError(t, err)
+	assert.NotContains(t, page, "Hidden Title")
+}
+
+func TestTableOfContents_Weight(t *testing.T) {
+	s, err := New(Options{Assets: NewMemorySource(map[string]string{
+		"a.md": "# Alpha\n",
+		"b.md": "---\nweight: 2\n---\n# Beta\n",
+		"c.md": "---\norder: 1.5\n---\n# Gamma\n",
+		"d.md": "# Delta\n",
+	})})
+	assert.NoError(t, err)
+
+	toc, err := s.TableOfContents()
+	assert.NoError(t, err)
+	var titles []string
+	for _, entry := range toc {
+		titles = append(titles, entry.Title)
+	}
+	assert.Equal(t, []string{"Gamma", "Beta", "Alpha", "Delta"}, titles)
+}
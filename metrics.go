@@ -0,0 +1,78 @@
+package help
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// MetricEvent is a navigation of the help window, as recorded in
+// `Options.MetricsFile`.
+type MetricEvent struct {
+	// Time is when the window was shown.
+	Time time.Time `json:"time"`
+	// Action is what showed it: "show", "show_at", "back", "forward", or
+	// "search".
+	Action string `json:"action"`
+	// Anchor is the anchor shown, or empty for the documentation root.
+	Anchor string `json:"anchor"`
+}
+
+// ReadMetrics decodes the navigation events recorded in a metrics file, one
+// `MetricEvent` per line, in the order they were recorded. Blank lines are
+// skipped.
+//
+// Example:
+//
+//	f, err := os.Open("help-metrics.jsonl")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer f.Close()
+//	events, err := help.ReadMetrics(f)
+func ReadMetrics(r io.Reader) ([]MetricEvent, error) {
+	var events []MetricEvent
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event MetricEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("help: metrics line %d: %w", line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// recordMetric appends a navigation to anchor, made by action, to
+// `Options.MetricsFile`, if set. As navigation has already succeeded,
+// errors are logged rather than returned.
+func (s *Service) recordMetric(action, anchor string) {
+	if s.opts.MetricsFile == "" {
+		return
+	}
+	data, err := json.Marshal(MetricEvent{Time: time.Now().UTC(), Action: action, Anchor: anchor})
+	if err != nil {
+		s.logger().Error(fmt.Sprintf("help: record metric: %v", err))
+		return
+	}
+	s.metricsMu.Lock()
+	defer s.metricsMu.Unlock()
+	f, err := os.OpenFile(s.opts.MetricsFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		s.logger().Error(fmt.Sprintf("help: record metric: %v", err))
+		return
+	}
+	_, err = f.Write(append(data, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		s.logger().Error(fmt.Sprintf("help: record metric: %v", err))
+	}
+}
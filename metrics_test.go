@@ -0,0 +1,41 @@
+package help
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "events.json")
+	s, _, _ := setupService(t, Options{Assets: testDocs, MetricsFile: file})
+
+	assert.NoError(t, s.Show())
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.NoError(t, s.ShowAt("any-anchor"))
+	assert.NoError(t, s.Back())
+	assert.Error(t, s.ShowAt("missing-anchor"))
+
+	f, err := os.Open(file)
+	assert.NoError(t, err)
+	defer f.Close()
+	events, err := ReadMetrics(f)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 4) {
+		assert.Equal(t, "show", events[0].Action)
+		assert.Equal(t, "", events[0].Anchor)
+		assert.Equal(t, "good-anchor", events[1].Anchor)
+		assert.Equal(t, "back", events[3].Action)
+		assert.Equal(t, "good-anchor", events[3].Anchor)
+		assert.False(t, events[0].Time.IsZero())
+	}
+}
+
+func TestMetricsFile_Unwritable(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs, MetricsFile: t.TempDir()})
+
+	assert.NoError(t, s.Show())
+	assert.True(t, mockCore.App().Logger().(*MockLogger).ErrorCalled)
+}
@@ -0,0 +1,103 @@
+package help
+
+import (
+	"context"
+	"errors"
+)
+
+// ShowModal opens the help window at anchor, or at the documentation root if
+// anchor is empty, and blocks until the window is closed, for flows where
+// the user must read the help before continuing. The window is opened as by
+// `ShowAt` or `Show`, and any error opening it is returned at once. In the
+// `wails3` fallback, the window's close event ends the wait; through a
+// `Display` service, the "display.window_closed" action does, delivered by
+// `HandleIPCEvents`. `Close` ends it too. In `Options.Headless` mode there
+// is no window to wait for, and ShowModal returns once help is shown. It is
+// an error with `TargetBrowser`, which cannot tell when the page is closed.
+//
+// Example:
+//
+//	if err := helpService.ShowModal("license"); err != nil {
+//		log.Println(err)
+//	}
+//	// The user has closed the license page.
+func (s *Service) ShowModal(anchor string) error {
+	return s.ShowModalContext(context.Background(), anchor)
+}
+
+// ShowModalContext is like `ShowModal`, but stops waiting and returns ctx's
+// error if ctx is cancelled or its deadline passes before the help window is
+// closed. The window is left open.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+//	defer cancel()
+//	err := helpService.ShowModalContext(ctx, "license")
+func (s *Service) ShowModalContext(ctx context.Context, anchor string) error {
+	if s.inBrowser() {
+		return errors.New("help: ShowModal is not supported with TargetBrowser")
+	}
+	closed := s.waitClose()
+	defer s.stopWaiting(closed)
+
+	var err error
+	if anchor == "" {
+		err = s.ShowContext(ctx)
+	} else {
+		err = s.ShowAtContext(ctx, anchor)
+	}
+	if err != nil {
+		return err
+	}
+	if s.display == nil && s.windowFactory() == nil && s.opts.Headless {
+		return nil
+	}
+
+	select {
+	case <-closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitClose returns a channel that is closed when the help window next
+// closes; see `emitClose`.
+func (s *Service) waitClose() chan struct{} {
+	ch := make(chan struct{})
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.closeWaiters = append(s.closeWaiters, ch)
+	return ch
+}
+
+// stopWaiting discards a channel returned by `waitClose`, if the window has
+// not closed yet.
+func (s *Service) stopWaiting(ch chan struct{}) {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.closeWaiters = removeWaiter(s.closeWaiters, ch)
+}
+
+// removeWaiter returns waiters without ch.
+func removeWaiter(waiters []chan struct{}, ch chan struct{}) []chan struct{} {
+	for i, waiter := range waiters {
+		if waiter == ch {
+			return append(waiters[:i], waiters[i+1:]...)
+		}
+	}
+	return waiters
+}
+
+// windowClosed records that the `Display` service has closed the help
+// window at the user's request.
+func (s *Service) windowClosed() {
+	s.mu.Lock()
+	open := s.displayOpen
+	s.displayOpen = false
+	s.mu.Unlock()
+	if open {
+		s.emitClose()
+	}
+}
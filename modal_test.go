@@ -0,0 +1,83 @@
+package help
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowModal_Close(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	done := make(chan error, 1)
+	go func() { done <- s.ShowModal("test-anchor") }()
+
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.displayOpen
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "help", mockDisplay.WindowName)
+	select {
+	case <-done:
+		t.Fatal("ShowModal returned before the window closed")
+	default:
+	}
+
+	assert.NoError(t, s.Close())
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("ShowModal did not return after Close")
+	}
+}
+
+func TestShowModal_WindowClosed(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	var closed int
+	s.OnClose(func() { closed++ })
+
+	done := make(chan error, 1)
+	go func() { done <- s.ShowModal("") }()
+
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.displayOpen
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, s.HandleIPCEvents(map[string]any{"action": "display.window_closed", "name": "other"}))
+	assert.NoError(t, s.HandleIPCEvents(map[string]any{"action": "display.window_closed", "name": "help"}))
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("ShowModal did not return after the window closed")
+	}
+	assert.Equal(t, 1, closed)
+}
+
+func TestShowModalContext_Timeout(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := s.ShowModalContext(ctx, "test-anchor")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	assert.Empty(t, s.closeWaiters)
+}
+
+func TestShowModal_Errors(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	assert.Error(t, s.ShowModal("missing-anchor"))
+
+	s, _, _ = setupService(t, Options{Assets: testDocs, Target: TargetBrowser})
+	assert.Error(t, s.ShowModal("test-anchor"))
+}
@@ -0,0 +1,103 @@
+package help
+
+import (
+	"io/fs"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// Option configures the help service when passed to `NewWithOptions`. Each
+// Option sets one field of the `Options` struct.
+type Option func(*Options)
+
+// WithSource sets the directory or URL that the help content is read from.
+// See `Options.Source`.
+func WithSource(source string) Option {
+	return func(o *Options) {
+		o.Source = source
+	}
+}
+
+// WithSources sets several sources to be layered in order, so that earlier
+// sources override pages of later ones. See `Options.Sources`.
+func WithSources(sources ...string) Option {
+	return func(o *Options) {
+		o.Sources = sources
+	}
+}
+
+// WithAssets sets the filesystem that the help content is read from. See
+// `Options.Assets`.
+func WithAssets(assets fs.FS) Option {
+	return func(o *Options) {
+		o.Assets = assets
+	}
+}
+
+// WithWindowSize sets the width and height of the help window.
+func WithWindowSize(width, height int) Option {
+	return func(o *Options) {
+		o.WindowWidth = width
+		o.WindowHeight = height
+	}
+}
+
+// WithWindowTitle sets the title of the help window.
+func WithWindowTitle(title string) Option {
+	return func(o *Options) {
+		o.WindowTitle = title
+	}
+}
+
+// WithWindowOptions sets the options for the help window created by the
+// `wails3` fallback; see `Options.WindowOptions`.
+func WithWindowOptions(options application.WebviewWindowOptions) Option {
+	return func(o *Options) {
+		o.WindowOptions = options
+	}
+}
+
+// WithLocale sets the locale of the documentation, and the default locale
+// to fall back to. See `Options.Locale` and `Options.DefaultLocale`.
+func WithLocale(locale, defaultLocale string) Option {
+	return func(o *Options) {
+		o.Locale = locale
+		o.DefaultLocale = defaultLocale
+	}
+}
+
+// WithLogger sets the logger the service reports to. See `Options.Logger`.
+func WithLogger(logger Logger) Option {
+	return func(o *Options) {
+		o.Logger = logger
+	}
+}
+
+// WithTheme sets the color scheme of the help content. See `Options.Theme`.
+func WithTheme(theme string) Option {
+	return func(o *Options) {
+		o.Theme = theme
+	}
+}
+
+// NewWithOptions creates a new instance of the help Service configured by
+// functional options. It is equivalent to calling `New` with an `Options`
+// struct that has had each option applied in order, and is convenient when
+// only a few settings differ from the defaults.
+//
+// Example:
+//
+//	helpService, err := help.NewWithOptions(
+//		help.WithSource("docs"),
+//		help.WithWindowSize(1200, 900),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func NewWithOptions(opts ...Option) (*Service, error) {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return New(o)
+}
@@ -0,0 +1,63 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	s, err := NewWithOptions(
+		WithAssets(testDocs),
+		WithWindowSize(1200, 900),
+		WithWindowTitle("Docs"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, testDocs, s.assets)
+	assert.Equal(t, 1200, s.opts.WindowWidth)
+	assert.Equal(t, 900, s.opts.WindowHeight)
+	assert.Equal(t, "Docs", s.opts.WindowTitle)
+}
+
+func TestNewWithOptions_Defaults(t *testing.T) {
+	s, err := NewWithOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, "mkdocs", s.opts.Source)
+	assert.Equal(t, "Help", s.opts.WindowTitle)
+	assert.Equal(t, 800, s.opts.WindowWidth)
+	assert.Equal(t, 600, s.opts.WindowHeight)
+}
+
+func TestWithSource(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewWithOptions(WithSource(dir))
+	assert.NoError(t, err)
+	assert.Equal(t, dir, s.opts.Source)
+}
+
+func TestWithWindowOptions(t *testing.T) {
+	s, err := NewWithOptions(WithWindowOptions(application.WebviewWindowOptions{AlwaysOnTop: true}))
+	assert.NoError(t, err)
+	assert.True(t, s.opts.WindowOptions.AlwaysOnTop)
+}
+
+func TestWithTheme(t *testing.T) {
+	s, err := NewWithOptions(WithTheme(ThemeDark))
+	assert.NoError(t, err)
+	assert.Equal(t, ThemeDark, s.opts.Theme)
+}
+
+func TestWithSources(t *testing.T) {
+	s, err := NewWithOptions(WithSources(t.TempDir(), "mkdocs"))
+	assert.NoError(t, err)
+	assert.Len(t, s.opts.Sources, 2)
+	assert.IsType(t, &overlayFS{}, s.assets)
+}
+
+func TestWithLocale(t *testing.T) {
+	s, err := NewWithOptions(WithAssets(localeDocs), WithLocale("de", "en"))
+	assert.NoError(t, err)
+	assert.Equal(t, "de", s.locale)
+	assert.Equal(t, "en", s.opts.DefaultLocale)
+}
@@ -0,0 +1,103 @@
+package help
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"sort"
+)
+
+// overlayFS is an fs.FS that layers several filesystems. A file is opened
+// from the first layer that has it, and a directory lists the entries of
+// every layer, with earlier layers shadowing later ones.
+type overlayFS struct {
+	layers []fs.FS
+}
+
+// Open opens the named file from the first layer that has it. A directory
+// lists the merged entries of every layer. If no layer has the file, the
+// first error other than `fs.ErrNotExist` is returned, or the first error if
+// every layer reports the file missing.
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	var first error
+	for _, layer := range o.layers {
+		f, err := layer.Open(name)
+		if err == nil {
+			return o.wrapDir(name, f)
+		}
+		if first == nil || errors.Is(first, fs.ErrNotExist) && !errors.Is(err, fs.ErrNotExist) {
+			first = err
+		}
+	}
+	if first == nil {
+		first = &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return nil, first
+}
+
+// ReadDir returns the union of the named directory's entries across all
+// layers, sorted by name. Layers where the directory does not exist are
+// skipped.
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	found := false
+	for _, layer := range o.layers {
+		list, err := fs.ReadDir(layer, name)
+		if err != nil {
+			continue
+		}
+		found = true
+		for _, entry := range list {
+			if !seen[entry.Name()] {
+				seen[entry.Name()] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+// wrapDir returns f, or an overlayDir listing the merged entries of every
+// layer if f is a directory.
+func (o *overlayFS) wrapDir(name string, f fs.File) (fs.File, error) {
+	info, err := f.Stat()
+	if err != nil || !info.IsDir() {
+		return f, err
+	}
+	entries, err := o.ReadDir(name)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &overlayDir{File: f, entries: entries}, nil
+}
+
+// overlayDir is a directory opened from an overlayFS.
+type overlayDir struct {
+	fs.File
+	entries []fs.DirEntry
+}
+
+// ReadDir returns the next n merged entries of the directory, as described
+// by `fs.ReadDirFile`.
+func (d *overlayDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(d.entries))
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
@@ -0,0 +1,89 @@
+package help
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlayFS(t *testing.T) {
+	overlay := &overlayFS{layers: []fs.FS{
+		fstest.MapFS{
+			"index.md":       {Data: []byte("# Custom\n")},
+			"guide/local.md": {Data: []byte("# Local\n")},
+		},
+		fstest.MapFS{
+			"index.md":         {Data: []byte("# Default\n")},
+			"guide/setup.md":   {Data: []byte("# Setup\n")},
+			"reference/api.md": {Data: []byte("# API\n")},
+		},
+	}}
+
+	data, err := fs.ReadFile(overlay, "index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Custom\n", string(data))
+
+	data, err = fs.ReadFile(overlay, "guide/setup.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Setup\n", string(data))
+
+	_, err = fs.ReadFile(overlay, "missing.md")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	var files []string
+	err = fs.WalkDir(overlay, ".", func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, p)
+		}
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"guide/local.md", "guide/setup.md", "index.md", "reference/api.md"}, files)
+
+	assert.NoError(t, fstest.TestFS(overlay, "index.md", "guide/local.md", "guide/setup.md", "reference/api.md"))
+}
+
+func TestNew_Sources(t *testing.T) {
+	override := t.TempDir()
+	err := os.WriteFile(filepath.Join(override, "index.md"), []byte("# Override\n"), 0o644)
+	assert.NoError(t, err)
+	fallback := t.TempDir()
+	err = os.WriteFile(filepath.Join(fallback, "index.md"), []byte("# Fallback\n"), 0o644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(fallback, "setup.md"), []byte("# Setup\n"), 0o644)
+	assert.NoError(t, err)
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	s, err := New(Options{Sources: []string{missing, override, fallback}})
+	assert.NoError(t, err)
+
+	data, err := s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Override\n", string(data))
+
+	data, err = s.ReadPage("setup.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Setup\n", string(data))
+
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"override", "setup"}, anchors)
+}
+
+func TestSetSource_Sources(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "index.md"), []byte("# Local\n"), 0o644)
+	assert.NoError(t, err)
+
+	err = s.SetSource(Options{Sources: []string{dir, "mkdocs"}})
+	assert.NoError(t, err)
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"local"}, anchors)
+}
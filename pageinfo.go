@@ -0,0 +1,99 @@
+package help
+
+import (
+	"io/fs"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PageInfo describes a documentation page, as listed by
+// `ListPagesWithInfo`.
+type PageInfo struct {
+	// Path is the path of the page, as `ListPages` reports it.
+	Path string `json:"path"`
+	// Title is the `title` of the page's front matter, or else the text of
+	// its first heading. It is empty if the page has neither.
+	Title string `json:"title"`
+	// ModTime is when the page was last modified. Embedded documentation
+	// carries no modification times, so its pages have the time of the
+	// commit the binary was built from, and the zero time if that is not
+	// known either.
+	ModTime time.Time `json:"modTime,omitzero"`
+}
+
+// ListPagesWithInfo returns the pages of the documentation, in the order
+// of `ListPages`, with the title and modification time of each, for
+// building a "recently updated" list. Pages hidden by `Options.PageFilter`
+// are left out, and remote sources list no pages.
+//
+// Example:
+//
+//	pages, err := helpService.ListPagesWithInfo()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	slices.SortFunc(pages, func(a, b help.PageInfo) int {
+//		return b.ModTime.Compare(a.ModTime)
+//	})
+func (s *Service) ListPagesWithInfo() ([]PageInfo, error) {
+	fsys := s.currentAssets()
+	var pages []PageInfo
+	err := walkDocPaths(fsys, s.warnWalk, func(p string) error {
+		info, err := fs.Stat(fsys, p)
+		if err != nil {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		modTime := info.ModTime()
+		if modTime.IsZero() {
+			modTime = buildTime()
+		}
+		pages = append(pages, PageInfo{Path: p, Title: pageTitle(p, data), ModTime: modTime})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// pageTitle returns the title of the documentation file p: the `title` of
+// its front matter, or else the text of its first heading.
+func pageTitle(p string, data []byte) string {
+	if isMarkdown(p) {
+		if meta, _, err := parseFrontMatter(data); err == nil {
+			if title, ok := meta["title"].(string); ok && strings.TrimSpace(title) != "" {
+				return strings.TrimSpace(title)
+			}
+		}
+	}
+	for _, sec := range fileSections(p, data) {
+		if sec.level > 0 {
+			return sec.title
+		}
+	}
+	return ""
+}
+
+// buildTime returns the time of the version control commit the binary was
+// built from, or the zero time if it was not recorded.
+var buildTime = sync.OnceValue(func() time.Time {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return time.Time{}
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.time" {
+			t, err := time.Parse(time.RFC3339, setting.Value)
+			if err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+})
@@ -0,0 +1,39 @@
+package help
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListPagesWithInfo(t *testing.T) {
+	updated := time.Date(2026, 3, 14, 9, 26, 0, 0, time.UTC)
+	docs := fstest.MapFS{
+		"index.md":       {Data: []byte("# Home\n\n## Getting Started\n"), ModTime: updated},
+		"guide/setup.md": {Data: []byte("---\ntitle: Setting Up\n---\n\n# Setup\n"), ModTime: updated.Add(time.Hour)},
+		"notes.md":       {Data: []byte("No headings here.\n"), ModTime: updated},
+		"logo.png":       {Data: []byte("png")},
+	}
+	s, _, _ := setupService(t, Options{Assets: docs})
+
+	pages, err := s.ListPagesWithInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []PageInfo{
+		{Path: "guide/setup.md", Title: "Setting Up", ModTime: updated.Add(time.Hour)},
+		{Path: "index.md", Title: "Home", ModTime: updated},
+		{Path: "notes.md", ModTime: updated},
+	}, pages)
+}
+
+func TestListPagesWithInfo_NoModTime(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	pages, err := s.ListPagesWithInfo()
+	assert.NoError(t, err)
+	if assert.Len(t, pages, 1) {
+		assert.Equal(t, "Test Anchor", pages[0].Title)
+		assert.Equal(t, buildTime(), pages[0].ModTime)
+	}
+}
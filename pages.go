@@ -0,0 +1,123 @@
+package help
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ReadPage returns the raw contents of the documentation file at path, which
+// is relative to the documentation root, such as "guide/setup.md". If the
+// file does not exist or is hidden by `Options.PageFilter`, the returned
+// error wraps `ErrNotFound`. A path
+// that would resolve outside the documentation root is rejected with an
+// error wrapping `ErrInvalidPath`. It reads the whole file into memory; use
+// `OpenPage` to stream large files.
+//
+// Example:
+//
+//	data, err := helpService.ReadPage("index.md")
+//	if errors.Is(err, help.ErrNotFound) {
+//		log.Println("no index page")
+//	}
+func (s *Service) ReadPage(path string) ([]byte, error) {
+	f, err := s.OpenPage(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("help: read page %q: %w", path, err)
+	}
+	return data, nil
+}
+
+// OpenPage opens the file at path in the documentation for streaming, such
+// as a tutorial video or a large image that the pages refer to, so that it
+// can be copied to an HTTP response or read incrementally. Paths are handled
+// as by `ReadPage`, and opening a directory is an error. The caller must
+// close the file.
+//
+// Example:
+//
+//	f, err := helpService.OpenPage("media/tour.mp4")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer f.Close()
+//	_, err = io.Copy(w, f)
+func (s *Service) OpenPage(path string) (fs.File, error) {
+	name, err := cleanPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if s.pageHidden(name) {
+		return nil, fmt.Errorf("help: page %q not found: %w", path, ErrNotFound)
+	}
+	f, err := s.currentAssets().Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("help: page %q not found: %w", path, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("help: open page %q: %w", path, err)
+	}
+	info, err := f.Stat()
+	if err == nil && info.IsDir() {
+		err = fmt.Errorf("%q is a directory", path)
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("help: open page %q: %w", path, err)
+	}
+	return f, nil
+}
+
+// ListPages returns the paths of every markdown and HTML file in the
+// documentation, in lexical order. The paths can be passed to `ReadPage`.
+// Symbolic links to directories are followed, as they are by `Anchors`,
+// `Search`, and `TableOfContents`; links that loop back to a directory
+// containing them, and broken links, are skipped with a logged warning.
+// Remote sources cannot be enumerated and list no pages.
+//
+// Example:
+//
+//	pages, err := helpService.ListPages()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, page := range pages {
+//		fmt.Println(page)
+//	}
+func (s *Service) ListPages() ([]string, error) {
+	var pages []string
+	err := walkDocPaths(s.currentAssets(), s.warnWalk, func(p string) error {
+		pages = append(pages, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// cleanPath checks a documentation path supplied by a caller and returns it
+// in canonical form, relative to the documentation root. Percent-encoding is
+// decoded first, so that an encoded "../" is caught too. Absolute paths,
+// backslashes, and paths that lead outside the root are rejected with an
+// error wrapping `ErrInvalidPath`.
+func cleanPath(p string) (string, error) {
+	decoded, err := url.PathUnescape(p)
+	if err != nil || decoded == "" || strings.HasPrefix(decoded, "/") || strings.ContainsAny(decoded, "\\\x00") {
+		return "", fmt.Errorf("help: %w %q", ErrInvalidPath, p)
+	}
+	clean := path.Clean(decoded)
+	if !fs.ValidPath(clean) {
+		return "", fmt.Errorf("help: %w %q", ErrInvalidPath, p)
+	}
+	return clean, nil
+}
@@ -0,0 +1,141 @@
+package help
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pageDocs = fstest.MapFS{
+	"index.md":         {Data: []byte("# Welcome\n")},
+	"guide/setup.html": {Data: []byte("<h1 id=\"setup\">Setup</h1>")},
+	"guide/logo.png":   {Data: []byte("png")},
+}
+
+func TestReadPage(t *testing.T) {
+	s, err := New(Options{Assets: pageDocs})
+	assert.NoError(t, err)
+
+	data, err := s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Welcome\n", string(data))
+}
+
+func TestReadPage_NotFound(t *testing.T) {
+	s, err := New(Options{Assets: pageDocs})
+	assert.NoError(t, err)
+
+	_, err = s.ReadPage("missing.md")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+	assert.Contains(t, err.Error(), `"missing.md"`)
+}
+
+func TestOpenPage(t *testing.T) {
+	s, err := New(Options{Assets: pageDocs})
+	assert.NoError(t, err)
+
+	f, err := s.OpenPage("guide/logo.png")
+	assert.NoError(t, err)
+	data, err := io.ReadAll(f)
+	assert.NoError(t, err)
+	assert.Equal(t, "png", string(data))
+	assert.NoError(t, f.Close())
+
+	_, err = s.OpenPage("missing.png")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = s.OpenPage("guide")
+	assert.Error(t, err)
+	_, err = s.OpenPage("../secret")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+}
+
+func TestListPages(t *testing.T) {
+	s, err := New(Options{Assets: pageDocs})
+	assert.NoError(t, err)
+
+	pages, err := s.ListPages()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"guide/setup.html", "index.md"}, pages)
+}
+
+// traversalPaths are attempts to escape the documentation root.
+var traversalPaths = []string{
+	"..",
+	"../secrets",
+	"guide/../../secrets",
+	"/etc/passwd",
+	"..%2Fsecrets",
+	"..%2fsecrets",
+	"%2e%2e/secrets",
+	"guide%2F..%2F..%2Fsecrets",
+	`..\secrets`,
+	"..%5csecrets",
+}
+
+func TestCleanPath(t *testing.T) {
+	for _, p := range traversalPaths {
+		_, err := cleanPath(p)
+		assert.ErrorIs(t, err, ErrInvalidPath, p)
+	}
+
+	tests := map[string]string{
+		"index.md":               "index.md",
+		"guide/setup.html":       "guide/setup.html",
+		"guide/./setup.html":     "guide/setup.html",
+		"guide/../index.md":      "index.md",
+		"guide%2Fsetup.html":     "guide/setup.html",
+		"guide//setup.html":      "guide/setup.html",
+		"with%20space/readme.md": "with space/readme.md",
+	}
+	for p, want := range tests {
+		got, err := cleanPath(p)
+		assert.NoError(t, err, p)
+		assert.Equal(t, want, got, p)
+	}
+}
+
+func TestPathTraversal(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: pageDocs})
+	out := filepath.Join(t.TempDir(), "out.pdf")
+	for _, p := range traversalPaths {
+		_, err := s.ReadPage(p)
+		assert.ErrorIs(t, err, ErrInvalidPath, "ReadPage %q", p)
+		_, err = s.RenderPage(p + "/index.md")
+		assert.ErrorIs(t, err, ErrInvalidPath, "RenderPage %q", p)
+		if strings.HasPrefix(p, "/") {
+			// A leading "/" on a page is the site root, as in a URL.
+			continue
+		}
+		assert.ErrorIs(t, s.ShowPage(p, "setup"), ErrInvalidPath, "ShowPage %q", p)
+		assert.ErrorIs(t, s.ShowAt(p+"#setup"), ErrInvalidPath, "ShowAt %q", p)
+		_, err = s.LinkFor(p + "#setup")
+		assert.ErrorIs(t, err, ErrInvalidPath, "LinkFor %q", p)
+		assert.ErrorIs(t, s.ExportPDF(p+"#setup", out), ErrInvalidPath, "ExportPDF %q", p)
+	}
+	assert.NoFileExists(t, out)
+}
+
+func TestServe_PathTraversal(t *testing.T) {
+	s, err := New(Options{Assets: pageDocs})
+	assert.NoError(t, err)
+	for _, p := range []string{"/../secrets", "/guide/../../secrets", "/..%2fsecrets", "/%2e%2e/secrets", "/..%5csecrets"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path, _ = url.PathUnescape(p)
+		req.URL.RawPath = p
+		rec := httptest.NewRecorder()
+		s.serveHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, p)
+	}
+
+	rec := serveRequest(s, "/guide/setup.html", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,60 @@
+package help
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ShowAtWithParams is like `ShowAt`, but adds params to the query of the
+// URL the help window opens, for documentation front ends that read them,
+// such as "?highlight=term" to highlight search hits:
+// "/guide?highlight=term#install". Params are URL-encoded, so values may
+// hold any text; a parameter with an empty name is an error. The anchor is
+// recorded in the navigation history without its params, and
+// `Options.NavigateDebounce` does not apply.
+//
+// Example:
+//
+//	params := url.Values{"highlight": {"billing"}}
+//	if err := helpService.ShowAtWithParams("billing-cycle", params); err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) ShowAtWithParams(anchor string, params url.Values) error {
+	anchor = s.resolveAnchor(anchor)
+	if _, fragment, _ := splitTarget(anchor); fragment == "" {
+		return fmt.Errorf("help: anchor must not be empty")
+	}
+	for name := range params {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("help: query parameter name must not be empty")
+		}
+	}
+	ctx := context.Background()
+	s.checkSourceOnce(ctx)
+	if err := s.openAtQuery(ctx, anchor, params, nil); err != nil {
+		return err
+	}
+	s.pushHistory(anchor)
+	s.emitShow("show_at", anchor)
+	return nil
+}
+
+// withQuery returns link with params added to its query, before any
+// fragment, keeping the parameters it already has, such as the theme.
+func withQuery(link string, params url.Values) string {
+	if len(params) == 0 {
+		return link
+	}
+	base, fragment, hasFragment := strings.Cut(link, "#")
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	link = base + sep + params.Encode()
+	if hasFragment {
+		link += "#" + fragment
+	}
+	return link
+}
@@ -0,0 +1,37 @@
+package help
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowAtWithParams(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	params := url.Values{"highlight": {"a b&c"}}
+	assert.NoError(t, s.ShowAtWithParams("good-anchor", params))
+	assert.Equal(t, "/?highlight=a+b%26c#good-anchor", mockDisplay.Options["URL"])
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+
+	// Without params, the URL is the one ShowAt opens.
+	assert.NoError(t, s.ShowAtWithParams("good-anchor", nil))
+	assert.Equal(t, "/#good-anchor", mockDisplay.Options["URL"])
+}
+
+func TestShowAtWithParams_Theme(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, Theme: ThemeDark})
+
+	assert.NoError(t, s.ShowAtWithParams("good-anchor", url.Values{"highlight": {"term"}}))
+	assert.Equal(t, "/?theme=dark&highlight=term#good-anchor", mockDisplay.Options["URL"])
+}
+
+func TestShowAtWithParams_Errors(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	assert.Error(t, s.ShowAtWithParams("", url.Values{"highlight": {"term"}}))
+	assert.Error(t, s.ShowAtWithParams("missing-anchor", url.Values{"highlight": {"term"}}))
+	assert.ErrorContains(t, s.ShowAtWithParams("good-anchor", url.Values{" ": {"term"}}), "name must not be empty")
+	assert.Nil(t, mockDisplay.Options)
+}
@@ -0,0 +1,23 @@
+package help
+
+import "fmt"
+
+// attachToParent makes window, a help window just created by the `wails3`
+// fallback, a child of the window named by `Options.ParentWindow`, so that
+// it stays above that window and is minimized and closed with it. It
+// returns an error if there is no such window or the platform does not
+// support child windows, in which case window is left a top-level window.
+func (s *Service) attachToParent(windows windowFactory, window helpWindow) error {
+	name := s.opts.ParentWindow
+	if name == "" {
+		return nil
+	}
+	parent, ok := windows.GetByName(name)
+	if !ok {
+		return fmt.Errorf("help: parent window %q not found", name)
+	}
+	if err := setOwner(window.NativeWindow(), parent.NativeWindow()); err != nil {
+		return fmt.Errorf("help: attach to parent window %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package help
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// setOwner makes the native window child an owned window of owner. Child
+// windows are only supported on Windows.
+func setOwner(child, owner unsafe.Pointer) error {
+	return fmt.Errorf("child windows are not supported on %s", runtime.GOOS)
+}
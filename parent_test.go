@@ -0,0 +1,54 @@
+//go:build !windows
+
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// errorLogger records the errors logged through it.
+type errorLogger struct{ messages []string }
+
+func (l *errorLogger) Info(message string, args ...any) {}
+func (l *errorLogger) Error(message string, args ...any) {
+	l.messages = append(l.messages, message)
+}
+
+func TestParentWindow_Unsupported(t *testing.T) {
+	tests := map[string]string{
+		"main":    `help: attach to parent window "main": child windows are not supported on`,
+		"missing": `help: parent window "missing" not found; opening a top-level help window`,
+	}
+	for name, want := range tests {
+		logger := &errorLogger{}
+		s, err := New(Options{Assets: testDocs, ParentWindow: name, Logger: logger})
+		assert.NoError(t, err)
+		windows := &fakeWindows{}
+		windows.add(&fakeWindow{options: application.WebviewWindowOptions{Name: "main"}})
+		s.windowing = windows
+
+		assert.NoError(t, s.Show(), name)
+		if created := windows.windows(); assert.Len(t, created, 1, name) {
+			_, shown, _ := created[0].state()
+			assert.True(t, shown, name)
+		}
+		if assert.Len(t, logger.messages, 1, name) {
+			assert.Contains(t, logger.messages[0], want, name)
+		}
+	}
+}
+
+func TestParentWindow_Unset(t *testing.T) {
+	logger := &errorLogger{}
+	s, err := New(Options{Assets: testDocs, Logger: logger})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+
+	assert.NoError(t, s.Show())
+	assert.Len(t, windows.windows(), 1)
+	assert.Empty(t, logger.messages)
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package help
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// gwlpHWNDParent is the `SetWindowLongPtrW` index of a window's owner.
+var gwlpHWNDParent = -8
+
+var setWindowLongPtr = syscall.NewLazyDLL("user32.dll").NewProc("SetWindowLongPtrW")
+
+// setOwner makes the native window child an owned window of owner, which
+// Windows keeps above its owner and minimizes with it.
+func setOwner(child, owner unsafe.Pointer) error {
+	if child == nil || owner == nil {
+		return errors.New("window not created yet")
+	}
+	if err := setWindowLongPtr.Find(); err != nil {
+		return err
+	}
+	application.InvokeSync(func() {
+		_, _, _ = setWindowLongPtr.Call(uintptr(child), uintptr(gwlpHWNDParent), uintptr(owner))
+	})
+	return nil
+}
@@ -0,0 +1,155 @@
+package help
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page layout of exported PDFs, in points: A4 paper with a 56pt margin.
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 56.0
+	pdfBodySize   = 11.0
+)
+
+// pdfWriter lays out headings and paragraphs of plain text as a simple PDF
+// document, using the standard Helvetica fonts so that nothing needs to be
+// embedded. Text outside Latin-1 is replaced with '?'.
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// heading adds a heading of the given level, from 1 to 6.
+func (w *pdfWriter) heading(level int, text string) {
+	size := max(20-2*float64(level), pdfBodySize+1)
+	w.space(size)
+	w.text("F2", size, text)
+}
+
+// paragraph adds a paragraph of body text.
+func (w *pdfWriter) paragraph(text string) {
+	w.space(pdfBodySize / 2)
+	w.text("F1", pdfBodySize, text)
+}
+
+// space adds vertical space before the next block, unless it would fall at
+// the top of a page.
+func (w *pdfWriter) space(height float64) {
+	if len(w.pages) > 0 && w.y < pdfPageHeight-pdfMargin {
+		w.y -= height
+	}
+}
+
+// text writes text in the given font and size, wrapped to the page width,
+// starting new pages as needed.
+func (w *pdfWriter) text(font string, size float64, text string) {
+	leading := size * 1.3
+	// Helvetica averages about half an em per character.
+	perLine := int((pdfPageWidth - 2*pdfMargin) / (size * 0.5))
+	for _, line := range wrapText(text, perLine) {
+		if len(w.pages) == 0 || w.y-leading < pdfMargin {
+			w.pages = append(w.pages, &bytes.Buffer{})
+			w.y = pdfPageHeight - pdfMargin
+		}
+		w.y -= leading
+		fmt.Fprintf(w.pages[len(w.pages)-1], "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n",
+			font, size, pdfMargin, w.y, pdfString(line))
+	}
+}
+
+// bytes returns the finished PDF document.
+func (w *pdfWriter) bytes() []byte {
+	if len(w.pages) == 0 {
+		w.pages = append(w.pages, &bytes.Buffer{})
+	}
+
+	// Objects 1-4 are the catalog, the page tree, and the two fonts; each
+	// page then takes two objects, for the page and its content stream.
+	var objects []string
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	for i, page := range w.pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()),
+		)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// wrapText splits text into lines of at most width characters, breaking at
+// spaces where possible.
+func wrapText(text string, width int) []string {
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+		for len(runes) > width {
+			if len(line) > 0 {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+		if len(line) > 0 && len(line)+1+len(runes) > width {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, runes...)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// pdfString encodes text as the contents of a PDF literal string in Latin-1,
+// escaping the characters that are special in PDF strings.
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r < 0x20 || r > 0xff:
+			b.WriteByte('?')
+		case r < 0x80:
+			b.WriteByte(byte(r))
+		default:
+			fmt.Fprintf(&b, "\\%03o", r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package help
+
+import (
+	"context"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// windowPoint is a screen position requested for the help window.
+type windowPoint struct {
+	x, y int
+}
+
+// ShowAtPosition is like `ShowAt`, but opens the help window with its
+// top-left corner at the screen coordinates x and y, such as the position
+// of the "?" icon the user clicked, so that contextual help appears next to
+// it as a popover would. The position is kept within the work area of the
+// screen it falls on, so the window never opens off-screen, and it takes
+// precedence over `Options.CenterOnShow`, `Options.WindowX`, and
+// `Options.WindowY`. An open help window is moved there. Coordinates are
+// only clamped when the `wails3` application reports its screens. Rapid
+// calls are coalesced by `Options.NavigateDebounce` as for ShowAt, and the
+// window opens at the position of the last one.
+//
+// Example:
+//
+//	// x and y are the screen position of the clicked help icon.
+//	if err := helpService.ShowAtPosition("billing-cycle", x, y); err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) ShowAtPosition(anchor string, x, y int) error {
+	return s.showAt(context.Background(), anchor, &windowPoint{x, y})
+}
+
+// clampToScreen returns the position closest to at at which a window of the
+// given size lies within the work area of the screen of app that at falls
+// on, or of the primary screen if at is on none of them. A window larger
+// than the work area is placed at its top-left corner. If app reports no
+// screens, at is returned unchanged.
+func clampToScreen(app *application.App, at windowPoint, width, height int) (x, y int) {
+	if app == nil || app.Screen == nil {
+		return at.x, at.y
+	}
+	var screen *application.Screen
+	for _, candidate := range app.Screen.GetAll() {
+		b := candidate.Bounds
+		if at.x >= b.X && at.x < b.X+b.Width && at.y >= b.Y && at.y < b.Y+b.Height {
+			screen = candidate
+			break
+		}
+	}
+	if screen == nil {
+		screen = app.Screen.GetPrimary()
+	}
+	if screen == nil {
+		return at.x, at.y
+	}
+	area := screen.WorkArea
+	return clamp(at.x, area.X, area.X+area.Width-width), clamp(at.y, area.Y, area.Y+area.Height-height)
+}
+
+// clamp returns v limited to the range from low to high, or low if the
+// range is empty.
+func clamp(v, low, high int) int {
+	if v > high {
+		v = high
+	}
+	if v < low {
+		v = low
+	}
+	return v
+}
@@ -0,0 +1,86 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// testScreens returns an application with two side-by-side 1000x800
+// screens, each with a 40 pixel taskbar at the bottom.
+func testScreens(t *testing.T) *application.App {
+	app := &application.App{Screen: &application.ScreenManager{}}
+	err := app.Screen.LayoutScreens([]*application.Screen{
+		{
+			ID:        "1",
+			IsPrimary: true,
+			Bounds:    application.Rect{Width: 1000, Height: 800},
+			WorkArea:  application.Rect{Width: 1000, Height: 760},
+		},
+		{
+			ID:       "2",
+			Bounds:   application.Rect{X: 1000, Width: 1000, Height: 800},
+			WorkArea: application.Rect{X: 1000, Width: 1000, Height: 760},
+		},
+	})
+	assert.NoError(t, err)
+	return app
+}
+
+func TestClampToScreen(t *testing.T) {
+	app := testScreens(t)
+	tests := []struct {
+		at   windowPoint
+		x, y int
+	}{
+		{windowPoint{100, 100}, 100, 100},
+		{windowPoint{900, 700}, 200, 160},
+		{windowPoint{1900, 10}, 1200, 10},
+		{windowPoint{-50, -50}, 0, 0},
+		{windowPoint{5000, 5000}, 200, 160},
+	}
+	for _, tt := range tests {
+		x, y := clampToScreen(app, tt.at, 800, 600)
+		assert.Equal(t, tt.x, x, "%v", tt.at)
+		assert.Equal(t, tt.y, y, "%v", tt.at)
+	}
+
+	// Without screens, the position is kept.
+	x, y := clampToScreen(&application.App{}, windowPoint{5000, 5000}, 800, 600)
+	assert.Equal(t, 5000, x)
+	assert.Equal(t, 5000, y)
+}
+
+func TestShowAtPosition(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, CenterOnShow: true})
+	s.app = testScreens(t)
+
+	assert.NoError(t, s.ShowAtPosition("good-anchor", 950, 20))
+	assert.Equal(t, "/#good-anchor", mockDisplay.Options["URL"])
+	assert.Equal(t, 200, mockDisplay.Options["X"])
+	assert.Equal(t, 20, mockDisplay.Options["Y"])
+	assert.NotContains(t, mockDisplay.Options, "Center")
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+
+	assert.Error(t, s.ShowAtPosition("missing-anchor", 10, 10))
+}
+
+func TestShowAtPosition_Wails(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	s.app = testScreens(t)
+	windows := &fakeWindows{}
+	s.windowing = windows
+
+	assert.NoError(t, s.ShowAtPosition("good-anchor", 1500, 500))
+	created := windows.windows()
+	assert.Len(t, created, 1)
+	assert.Equal(t, application.WindowXY, created[0].options.InitialPosition)
+	assert.Equal(t, application.Rect{X: 1200, Y: 160, Width: 800, Height: 600}, created[0].Bounds())
+
+	// An open window is moved.
+	assert.NoError(t, s.ShowAtPosition("good-anchor", 10, 20))
+	assert.Len(t, windows.windows(), 1)
+	assert.Equal(t, application.Rect{X: 10, Y: 20, Width: 800, Height: 600}, created[0].Bounds())
+}
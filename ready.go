@@ -0,0 +1,102 @@
+package help
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ShowAndWait opens the help window at anchor, or at the documentation root
+// if anchor is empty, and returns once its page has loaded, so that code
+// run afterwards, such as a script that highlights a term, finds the page
+// ready. The window is opened as by `ShowAt` or `Show`, and any error
+// opening it is returned at once. In the `wails3` fallback, the window's
+// runtime-ready event ends the wait, and moving within a page that is
+// already loaded returns at once; through a `Display` service, the
+// "display.window_ready" action does, delivered by `HandleIPCEvents`. In
+// `Options.Headless` mode there is no page to wait for, and ShowAndWait
+// returns once help is shown. It is an error with `TargetBrowser`, which
+// cannot tell when the page has loaded.
+//
+// Example:
+//
+//	if err := helpService.ShowAndWait("shortcuts"); err != nil {
+//		log.Println(err)
+//	}
+//	// The shortcuts page has loaded.
+func (s *Service) ShowAndWait(anchor string) error {
+	return s.ShowAndWaitContext(context.Background(), anchor)
+}
+
+// ShowAndWaitContext is like `ShowAndWait`, but stops waiting and returns
+// ctx's error if ctx is cancelled or its deadline passes before the page
+// has loaded.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	err := helpService.ShowAndWaitContext(ctx, "shortcuts")
+func (s *Service) ShowAndWaitContext(ctx context.Context, anchor string) error {
+	if s.inBrowser() {
+		return errors.New("help: ShowAndWait is not supported with TargetBrowser")
+	}
+	ready := s.waitReady()
+	defer s.stopWaitingReady(ready)
+
+	var err error
+	if anchor == "" {
+		err = s.ShowContext(ctx)
+	} else {
+		err = s.ShowAtContext(ctx, anchor)
+	}
+	if err != nil {
+		return err
+	}
+	if s.display == nil && s.windowFactory() == nil && s.opts.Headless {
+		return nil
+	}
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitReady returns a channel that is closed when the help window next
+// finishes loading a page; see `emitReady`.
+func (s *Service) waitReady() chan struct{} {
+	ch := make(chan struct{})
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.readyWaiters = append(s.readyWaiters, ch)
+	return ch
+}
+
+// stopWaitingReady discards a channel returned by `waitReady`, if the page
+// has not loaded yet.
+func (s *Service) stopWaitingReady(ch chan struct{}) {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.readyWaiters = removeWaiter(s.readyWaiters, ch)
+}
+
+// emitReady releases the `ShowAndWait` calls waiting for the help window's
+// page to load.
+func (s *Service) emitReady() {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	for _, ch := range s.readyWaiters {
+		close(ch)
+	}
+	s.readyWaiters = nil
+}
+
+// pageOf returns url without its fragment: the page that the help window
+// loads for it.
+func pageOf(url string) string {
+	page, _, _ := strings.Cut(url, "#")
+	return page
+}
@@ -0,0 +1,90 @@
+package help
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wailsapp/wails/v3/pkg/events"
+)
+
+func TestShowAndWait_WindowReady(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	done := make(chan error, 1)
+	go func() { done <- s.ShowAndWait("test-anchor") }()
+
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.displayOpen
+	}, time.Second, 10*time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("ShowAndWait returned before the page loaded")
+	default:
+	}
+
+	assert.NoError(t, s.HandleIPCEvents(map[string]any{"action": "display.window_ready", "name": "other"}))
+	assert.NoError(t, s.HandleIPCEvents(map[string]any{"action": "display.window_ready", "name": "help"}))
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("ShowAndWait did not return after the page loaded")
+	}
+}
+
+func TestShowAndWait_Fallback(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+
+	done := make(chan error, 1)
+	go func() { done <- s.ShowAndWait("test-anchor") }()
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.window != nil
+	}, time.Second, 10*time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("ShowAndWait returned before the page loaded")
+	default:
+	}
+	windows.windows()[0].emit(events.Common.WindowRuntimeReady)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("ShowAndWait did not return after the page loaded")
+	}
+
+	// Moving within the loaded page returns at once.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, s.ShowAndWaitContext(ctx, "good-anchor"))
+}
+
+func TestShowAndWaitContext_Timeout(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := s.ShowAndWaitContext(ctx, "test-anchor")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	assert.Empty(t, s.readyWaiters)
+}
+
+func TestShowAndWait_Errors(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	assert.Error(t, s.ShowAndWait("missing-anchor"))
+
+	s, _, _ = setupService(t, Options{Assets: testDocs, Target: TargetBrowser})
+	assert.Error(t, s.ShowAndWait("test-anchor"))
+}
@@ -0,0 +1,97 @@
+package help
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// Related returns the other pages that share front matter tags with the
+// documentation file at path, for "Related" links at the bottom of a page.
+// Pages sharing the most tags come first, and pages sharing as many follow
+// their order in `TableOfContents`. Each page is given by the entry of its
+// first heading, without the headings under it. Tags are compared without
+// regard to case. A page without tags has no related pages, and pages
+// without headings are left out. Paths are handled as by `ReadPage`.
+//
+// Example:
+//
+//	related, err := helpService.Related("billing/invoices.md")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, entry := range related {
+//		fmt.Printf("See also: %s (%s#%s)\n", entry.Title, entry.Path, entry.Anchor)
+//	}
+func (s *Service) Related(path string) ([]TOCEntry, error) {
+	p, err := cleanPath(path)
+	if err != nil {
+		return nil, err
+	}
+	index, err := s.contentIndex(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := index.sections[p]; !ok {
+		return nil, fmt.Errorf("help: page %q: %w", path, fs.ErrNotExist)
+	}
+
+	related := []TOCEntry{}
+	tags := make(map[string]bool, len(index.tags[p]))
+	for _, tag := range index.tags[p] {
+		tags[tag] = true
+	}
+	if len(tags) == 0 {
+		return related, nil
+	}
+	overlap := make(map[string]int)
+	for _, entry := range index.toc {
+		if entry.Path == p {
+			continue
+		}
+		if _, ok := overlap[entry.Path]; ok {
+			continue
+		}
+		shared := 0
+		for _, tag := range index.tags[entry.Path] {
+			if tags[tag] {
+				shared++
+			}
+		}
+		overlap[entry.Path] = shared
+		if shared > 0 {
+			entry.Children = nil
+			related = append(related, entry)
+		}
+	}
+	sort.SliceStable(related, func(i, j int) bool {
+		return overlap[related[i].Path] > overlap[related[j].Path]
+	})
+	return related, nil
+}
+
+// pageTags returns the `tags` of front matter, lowercased and without
+// duplicates. Tags may be given as a list or as a single string.
+func pageTags(meta map[string]any) []string {
+	var values []any
+	switch v := meta["tags"].(type) {
+	case []any:
+		values = v
+	case string:
+		values = []any{v}
+	}
+	var tags []string
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		tag, ok := value.(string)
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !ok || tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -0,0 +1,49 @@
+package help
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var relatedDocs = NewMemorySource(map[string]string{
+	"invoices.md": "---\ntags: [billing, Payments, pdf]\n---\n# Invoices\n\n## Download\n",
+	"refunds.md":  "---\ntags: [billing, payments]\n---\n# Refunds\n",
+	"plans.md":    "---\ntags: billing\nweight: 1\n---\n# Plans\n",
+	"export.md":   "---\ntags: [pdf]\n---\n# Export\n",
+	"setup.md":    "---\ntags: [install]\n---\n# Setup\n",
+	"untagged.md": "# Untagged\n",
+})
+
+func TestRelated(t *testing.T) {
+	s, err := New(Options{Assets: relatedDocs})
+	assert.NoError(t, err)
+
+	related, err := s.Related("invoices.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []TOCEntry{
+		{Title: "Refunds", Anchor: "refunds", Level: 1, Path: "refunds.md"},
+		{Title: "Plans", Anchor: "plans", Level: 1, Path: "plans.md"},
+		{Title: "Export", Anchor: "export", Level: 1, Path: "export.md"},
+	}, related)
+
+	related, err = s.Related("untagged.md")
+	assert.NoError(t, err)
+	assert.NotNil(t, related)
+	assert.Empty(t, related)
+
+	related, err = s.Related("setup.md")
+	assert.NoError(t, err)
+	assert.Empty(t, related)
+}
+
+func TestRelated_Errors(t *testing.T) {
+	s, err := New(Options{Assets: relatedDocs})
+	assert.NoError(t, err)
+
+	_, err = s.Related("missing.md")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = s.Related("../secrets.md")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+}
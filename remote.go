@@ -0,0 +1,169 @@
+package help
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// isRemoteSource reports whether source is an HTTP or HTTPS URL.
+func isRemoteSource(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// fetchTimeout is how long an httpFS waits for a file before giving up.
+var fetchTimeout = 30 * time.Second
+
+// httpFS is an fs.FS backed by documentation hosted on a web server. Files
+// are fetched with a GET request relative to base, and kept in cache, if
+// set, for reuse. HTTP offers no directory listing, so directories open as
+// empty and content cannot be enumerated.
+type httpFS struct {
+	base   *url.URL
+	client *http.Client
+	cache  *pageCache
+}
+
+// newHTTPFS returns an httpFS rooted at the given URL that fetches files with
+// client, or with a client that times out after `fetchTimeout` if client is
+// nil, and caches them for ttl; see `Options.CacheTTL`.
+func newHTTPFS(source string, client *http.Client, ttl time.Duration) (*httpFS, error) {
+	base, err := url.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("help: invalid source %q: %w", source, err)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	if client == nil {
+		client = &http.Client{Timeout: fetchTimeout}
+	}
+	return &httpFS{base: base, client: client, cache: newPageCache(ttl)}, nil
+}
+
+// Open fetches the named file from the web server, giving up after
+// `fetchTimeout`. A file in the cache is returned from it while fresh; once
+// stale, it is revalidated with the server's ETag or Last-Modified
+// validators and kept if unchanged.
+func (h *httpFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return &httpDir{name: name}, nil
+	}
+
+	link := h.url(name)
+	cached, ok, fresh := h.cache.get(link)
+	if fresh {
+		return cached.file(name), nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if ok {
+		cached.revalidate(req)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		h.cache.put(link, cached)
+		return cached.file(name), nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case resp.StatusCode != http.StatusOK:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fmt.Errorf("unexpected status %s", resp.Status)}
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	page := cachedPage{
+		data:         data,
+		modTime:      modTime,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+	h.cache.put(link, page)
+	return page.file(name), nil
+}
+
+// url returns the absolute URL of the named file.
+func (h *httpFS) url(name string) string {
+	return h.base.ResolveReference(&url.URL{Path: name}).String()
+}
+
+// ping checks that the web server hosting the documentation is reachable.
+func (h *httpFS) ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, h.base.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// httpFile is a file held in memory, such as one fetched by httpFS.
+type httpFile struct {
+	*bytes.Reader
+	info httpFileInfo
+}
+
+func (f *httpFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *httpFile) Close() error               { return nil }
+
+// httpDir is a directory of an httpFS. It is always empty.
+type httpDir struct {
+	name string
+}
+
+func (d *httpDir) Stat() (fs.FileInfo, error) {
+	return httpFileInfo{name: d.name, dir: true}, nil
+}
+func (d *httpDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+func (d *httpDir) Close() error                       { return nil }
+func (d *httpDir) ReadDir(int) ([]fs.DirEntry, error) { return nil, nil }
+
+// httpFileInfo describes a file or directory of an httpFS.
+type httpFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func (i httpFileInfo) Name() string       { return i.name }
+func (i httpFileInfo) Size() int64        { return i.size }
+func (i httpFileInfo) ModTime() time.Time { return i.modTime }
+func (i httpFileInfo) IsDir() bool        { return i.dir }
+func (i httpFileInfo) Sys() any           { return nil }
+func (i httpFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
@@ -0,0 +1,129 @@
+package help
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDocsServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/docs/index.md", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("# Remote\n"))
+	})
+	mux.HandleFunc("/docs/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/docs/" {
+			http.NotFound(w, r)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNew_RemoteSource(t *testing.T) {
+	server := newDocsServer(t)
+	s, err := New(Options{Source: server.URL + "/docs"})
+	assert.NoError(t, err)
+
+	data, err := fs.ReadFile(s.assets, "index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Remote\n", string(data))
+
+	_, err = fs.ReadFile(s.assets, "missing.md")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+// roundTripperFunc adapts a function to an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNew_RemoteSourceHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("# Internal\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	s, err := New(Options{Source: server.URL + "/docs"})
+	assert.NoError(t, err)
+	_, err = fs.ReadFile(s.assets, "index.md")
+	assert.Error(t, err)
+
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		r = r.Clone(r.Context())
+		r.Header.Set("Authorization", "Bearer secret")
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	s, err = New(Options{Sources: []string{server.URL + "/docs"}, HTTPClient: client})
+	assert.NoError(t, err)
+	data, err := fs.ReadFile(s.assets, "index.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Internal\n", string(data))
+}
+
+func TestHTTPFS_Timeout(t *testing.T) {
+	defer func(timeout time.Duration) { fetchTimeout = timeout }(fetchTimeout)
+	fetchTimeout = 50 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+
+	fsys, err := newHTTPFS(server.URL+"/docs", nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, fetchTimeout, fsys.client.Timeout)
+	_, err = fsys.Open("index.md")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A client without a timeout of its own gives up too.
+	fsys, err = newHTTPFS(server.URL+"/docs", &http.Client{}, 0)
+	assert.NoError(t, err)
+	_, err = fsys.Open("index.md")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestShow_RemoteSource(t *testing.T) {
+	server := newDocsServer(t)
+	s, _, mockDisplay := setupService(t, Options{Source: server.URL + "/docs"})
+
+	err := s.Show()
+	assert.NoError(t, err)
+	opts := mockDisplay.Options
+	assert.Equal(t, server.URL+"/docs/", opts["URL"])
+
+	// Anchors on a remote source cannot be enumerated, so they are not
+	// validated.
+	err = s.ShowAt("anywhere")
+	assert.NoError(t, err)
+	opts = mockDisplay.Options
+	assert.Equal(t, server.URL+"/docs/#anywhere", opts["URL"])
+}
+
+func TestServiceStartup_RemoteSource(t *testing.T) {
+	server := newDocsServer(t)
+	s, _, _ := setupService(t, Options{Source: server.URL + "/docs"})
+
+	err := s.ServiceStartup(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestServiceStartup_RemoteSourceUnreachable(t *testing.T) {
+	server := newDocsServer(t)
+	s, _, _ := setupService(t, Options{Source: server.URL + "/docs"})
+	server.Close()
+
+	err := s.ServiceStartup(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unreachable")
+}
@@ -0,0 +1,178 @@
+package help
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// markdown converts documentation markdown to HTML. Headings get IDs, and
+// explicit `{#id}` attributes are honoured, so that rendered pages can be
+// navigated with `ShowAt`.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(
+		parser.WithAutoHeadingID(),
+		parser.WithAttribute(),
+	),
+)
+
+// Renderer converts markdown to HTML. Set `Options.Renderer` to render
+// documentation pages with a markdown flavor or extensions of your own
+// choosing. Render is given the markdown of a page, without its front
+// matter and expanded with `Options.TemplateData`, and returns its HTML. To
+// keep `ShowAt` and `SectionHTML` working, headings should be given the IDs
+// listed by `Anchors`, as `GFMRenderer` does.
+//
+// Example:
+//
+//	type mermaidRenderer struct{ md goldmark.Markdown }
+//
+//	func (r mermaidRenderer) Render(source []byte) ([]byte, error) {
+//		var buf bytes.Buffer
+//		err := r.md.Convert(source, &buf)
+//		return buf.Bytes(), err
+//	}
+type Renderer interface {
+	Render(source []byte) ([]byte, error)
+}
+
+// GFMRenderer is the built-in `Renderer`. It converts GitHub Flavored
+// Markdown, giving headings the IDs listed by `Anchors` and honouring
+// explicit `{#id}` attributes. Embed it to wrap the built-in rendering, for
+// example to post-process its HTML.
+type GFMRenderer struct{}
+
+// Render converts the markdown source to HTML.
+func (GFMRenderer) Render(source []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := markdown.Renderer().Render(&buf, source, parseMarkdown(source)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseMarkdown parses the markdown source with heading IDs generated as by
+// `markdownHeadings`.
+func parseMarkdown(source []byte) ast.Node {
+	ctx := parser.NewContext(parser.WithIDs(&headingIDs{used: make(map[string]int)}))
+	return markdown.Parser().Parse(text.NewReader(source), parser.WithContext(ctx))
+}
+
+// RenderPage returns the documentation file at path as HTML. Markdown files
+// are converted, with heading IDs that match the anchors listed by `Anchors`
+// and accepted by `ShowAt`, and links to other markdown files are pointed
+// at those pages in the help window; HTML files are returned as they are.
+// Both are first expanded with `Options.TemplateData`. Markdown is rendered
+// by `Options.Renderer` if set. Any other file is an error.
+//
+// Example:
+//
+//	page, err := helpService.RenderPage("guide/setup.md")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(page)
+func (s *Service) RenderPage(path string) (string, error) {
+	if !isDocFile(path) {
+		return "", fmt.Errorf("help: cannot render %q: not a markdown or HTML file", path)
+	}
+	data, err := s.ReadPage(path)
+	if err != nil {
+		return "", err
+	}
+	if isHTML(path) {
+		return string(expandTemplate(path, data, s.templateData())), nil
+	}
+	_, data = splitFrontMatter(data)
+	data = expandTemplate(path, data, s.templateData())
+
+	if s.opts.Renderer != nil {
+		page, err := s.opts.Renderer.Render(data)
+		if err != nil {
+			return "", fmt.Errorf("help: render page %q: %w", path, err)
+		}
+		return string(page), nil
+	}
+
+	var buf bytes.Buffer
+	doc := parseMarkdown(data)
+	s.rewriteLinks(doc, path)
+	if err := markdown.Renderer().Render(&buf, data, doc); err != nil {
+		return "", fmt.Errorf("help: render page %q: %w", path, err)
+	}
+	return buf.String(), nil
+}
+
+// rewriteLinks points the links of the markdown document doc, read from the
+// file at page, that refer to other markdown files, such as "setup.md#install",
+// at the URLs those files have in the help window, such as "/setup#install",
+// so that they can be followed there. Relative links are resolved against
+// page. Links with a scheme or host, such as "https://" links, are left as
+// they are.
+func (s *Service) rewriteLinks(doc ast.Node, page string) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if link, ok := n.(*ast.Link); ok && entering {
+			if target, ok := markdownLinkTarget(string(link.Destination), page); ok {
+				link.Destination = []byte(s.pageURL(target))
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// markdownLinkTarget returns the navigation target, in the `page#anchor`
+// form accepted by `pageURL`, of a link to a markdown file found in the file
+// at page, and reports whether dest is such a link. The target names the
+// page as the site URL mkdocs builds for it: "guide/setup" for
+// "guide/setup.md", and "guide/" for "guide/index.md".
+func markdownLinkTarget(dest, page string) (string, bool) {
+	u, err := url.Parse(dest)
+	if err != nil || u.Scheme != "" || u.Host != "" || !strings.EqualFold(path.Ext(u.Path), ".md") {
+		return "", false
+	}
+	file := u.Path
+	if !strings.HasPrefix(file, "/") {
+		file = path.Join(path.Dir(page), file)
+	}
+	file = strings.TrimPrefix(path.Clean("/"+file), "/")
+	stem := file[:len(file)-len(".md")]
+	switch {
+	case stem == "index":
+		stem = ""
+	case path.Base(stem) == "index":
+		stem = path.Dir(stem) + "/"
+	}
+	return stem + "#" + u.Fragment, true
+}
+
+// headingIDs generates heading IDs for the markdown renderer in the same way
+// as `markdownHeadings`: headings are slugified, and repeated slugs within a
+// document are suffixed with `_1`, `_2`, and so on.
+type headingIDs struct {
+	used map[string]int
+}
+
+// Generate returns the ID for a heading with the given markdown text.
+func (ids *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	anchor := slugify(string(value))
+	if n, ok := ids.used[anchor]; ok {
+		ids.used[anchor] = n + 1
+		anchor = fmt.Sprintf("%s_%d", anchor, n+1)
+	}
+	ids.used[anchor] = 0
+	return []byte(anchor)
+}
+
+// Put records an explicit heading ID.
+func (ids *headingIDs) Put(value []byte) {
+	ids.used[string(value)] = 0
+}
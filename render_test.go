@@ -0,0 +1,122 @@
+package help
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var renderDocs = fstest.MapFS{
+	"index.md": {Data: []byte("# Welcome\n\n## Getting Started\n\n## Getting Started\n\n" +
+		"### Using `ShowAt()`\n\n### Custom {#my-id}\n\n" +
+		"See [the guide](guide.html).\n\n```go\nfmt.Println(\"hi\")\n```\n")},
+	"guide.html": {Data: []byte(`<h1 id="guide">Guide</h1>`)},
+	"logo.png":   {Data: []byte("png")},
+}
+
+func TestRenderPage(t *testing.T) {
+	s, err := New(Options{Assets: renderDocs})
+	assert.NoError(t, err)
+
+	page, err := s.RenderPage("index.md")
+	assert.NoError(t, err)
+	assert.Contains(t, page, `<h1 id="welcome">Welcome</h1>`)
+	assert.Contains(t, page, `<h2 id="getting-started_1">Getting Started</h2>`)
+	assert.Contains(t, page, `<h3 id="my-id">Custom</h3>`)
+	assert.Contains(t, page, `<a href="guide.html">the guide</a>`)
+	assert.Contains(t, page, `<pre><code class="language-go">`)
+}
+
+func TestRenderPage_IDsMatchAnchors(t *testing.T) {
+	s, err := New(Options{Assets: renderDocs})
+	assert.NoError(t, err)
+
+	page, err := s.RenderPage("index.md")
+	assert.NoError(t, err)
+	anchors := fileAnchors("index.md", renderDocs["index.md"].Data)
+	assert.Len(t, anchors, 5)
+	for _, anchor := range anchors {
+		assert.True(t, strings.Contains(page, `id="`+anchor+`"`), anchor)
+	}
+}
+
+func TestRenderPage_HTML(t *testing.T) {
+	s, err := New(Options{Assets: renderDocs})
+	assert.NoError(t, err)
+
+	page, err := s.RenderPage("guide.html")
+	assert.NoError(t, err)
+	assert.Equal(t, `<h1 id="guide">Guide</h1>`, page)
+}
+
+func TestRenderPage_Errors(t *testing.T) {
+	s, err := New(Options{Assets: renderDocs})
+	assert.NoError(t, err)
+
+	_, err = s.RenderPage("logo.png")
+	assert.Error(t, err)
+	_, err = s.RenderPage("missing.md")
+	assert.Error(t, err)
+}
+
+func TestRenderPage_Links(t *testing.T) {
+	docs := NewMemorySource(map[string]string{
+		"index.md": "# Home\n\n[setup](guide/setup.md#install) [site](https://example.com/page.md) [mail](mailto:help@example.com)\n",
+		"guide/setup.md": "# Setup\n\n[home](../index.md) [faq](faq.md) [guide](index.md#top) " +
+			"[root](/about.md) [image](diagram.png)\n",
+	})
+	s, err := New(Options{Assets: docs})
+	assert.NoError(t, err)
+
+	page, err := s.RenderPage("index.md")
+	assert.NoError(t, err)
+	assert.Contains(t, page, `<a href="/guide/setup.html#install">setup</a>`)
+	assert.Contains(t, page, `<a href="https://example.com/page.md">site</a>`)
+	assert.Contains(t, page, `<a href="mailto:help@example.com">mail</a>`)
+
+	page, err = s.RenderPage("guide/setup.md")
+	assert.NoError(t, err)
+	assert.Contains(t, page, `<a href="/">home</a>`)
+	assert.Contains(t, page, `<a href="/guide/faq">faq</a>`)
+	assert.Contains(t, page, `<a href="/guide/#top">guide</a>`)
+	assert.Contains(t, page, `<a href="/about">root</a>`)
+	assert.Contains(t, page, `<a href="diagram.png">image</a>`)
+}
+
+// upperRenderer is a `Renderer` that wraps the built-in one and upper-cases
+// its output, or fails if err is set.
+type upperRenderer struct {
+	GFMRenderer
+	err error
+}
+
+func (r upperRenderer) Render(source []byte) ([]byte, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	page, err := r.GFMRenderer.Render(source)
+	return bytes.ToUpper(page), err
+}
+
+func TestRenderPage_Renderer(t *testing.T) {
+	s, err := New(Options{Assets: renderDocs, Renderer: upperRenderer{}})
+	assert.NoError(t, err)
+
+	page, err := s.RenderPage("index.md")
+	assert.NoError(t, err)
+	assert.Contains(t, page, `<H1 ID="WELCOME">WELCOME</H1>`)
+
+	// HTML pages are not rendered.
+	page, err = s.RenderPage("guide.html")
+	assert.NoError(t, err)
+	assert.Equal(t, `<h1 id="guide">Guide</h1>`, page)
+
+	s, err = New(Options{Assets: renderDocs, Renderer: upperRenderer{err: errors.New("boom")}})
+	assert.NoError(t, err)
+	_, err = s.RenderPage("index.md")
+	assert.ErrorContains(t, err, `help: render page "index.md": boom`)
+}
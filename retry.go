@@ -0,0 +1,37 @@
+package help
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultActionRetryDelay is the wait before the first retry of a failed
+// dispatch when `Options.ActionRetries` is set without a delay.
+const defaultActionRetryDelay = 100 * time.Millisecond
+
+// dispatch calls send, which hands a message to the core runtime or the
+// `Display` service, and retries it up to `Options.ActionRetries` times if
+// it fails, waiting `Options.ActionRetryDelay` before the first retry and
+// twice as long before each one after. Retrying stops when ctx is done. The
+// error of the last attempt is returned.
+func (s *Service) dispatch(ctx context.Context, send func() error) error {
+	err := send()
+	delay := s.opts.ActionRetryDelay
+	if delay <= 0 {
+		delay = defaultActionRetryDelay
+	}
+	for attempt := 1; err != nil && attempt <= s.opts.ActionRetries; attempt++ {
+		s.logger().Info(fmt.Sprintf("help: dispatch failed, retrying in %s: %v", delay, err))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		err = send()
+		delay *= 2
+	}
+	return err
+}
@@ -0,0 +1,83 @@
+package help
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyDisplay is a `Display` whose OpenWindow fails until it has been
+// called failures times.
+type flakyDisplay struct {
+	NopDisplay
+	mu       sync.Mutex
+	failures int
+	calls    int
+}
+
+func (d *flakyDisplay) OpenWindow(name string, options map[string]any) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls++
+	if d.calls <= d.failures {
+		return errors.New("core not ready")
+	}
+	return nil
+}
+
+func (d *flakyDisplay) callCount() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.calls
+}
+
+func TestActionRetries(t *testing.T) {
+	s, err := New(Options{Assets: testDocs, ActionRetries: 3, ActionRetryDelay: time.Millisecond})
+	assert.NoError(t, err)
+	display := &flakyDisplay{failures: 2}
+	s.Init(&MockCore{}, display)
+
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.Equal(t, 3, display.callCount())
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+}
+
+func TestActionRetries_Exhausted(t *testing.T) {
+	s, err := New(Options{Assets: testDocs, ActionRetries: 2, ActionRetryDelay: time.Millisecond})
+	assert.NoError(t, err)
+	display := &flakyDisplay{failures: 5}
+	s.Init(&MockCore{}, display)
+
+	assert.EqualError(t, s.Show(), "core not ready")
+	assert.Equal(t, 3, display.callCount())
+
+	// Without retries, the first error is returned.
+	s.opts.ActionRetries = 0
+	assert.Error(t, s.Show())
+	assert.Equal(t, 4, display.callCount())
+}
+
+func TestActionRetries_Context(t *testing.T) {
+	s, err := New(Options{Assets: testDocs, ActionRetries: 5, ActionRetryDelay: time.Hour})
+	assert.NoError(t, err)
+	display := &flakyDisplay{failures: 5}
+	s.Init(&MockCore{}, display)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.ShowContext(ctx), context.DeadlineExceeded)
+	assert.Equal(t, 1, display.callCount())
+}
+
+func TestActionRetries_Reload(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, ActionRetries: 1, ActionRetryDelay: time.Millisecond})
+	assert.NoError(t, s.Show())
+
+	mockDisplay.Err = errors.New("display not ready")
+	assert.Error(t, s.Reload())
+	assert.True(t, mockDisplay.ReloadCalled)
+}
@@ -0,0 +1,299 @@
+package help
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// searchPage is the built-in search page. mkdocs copies it into the built
+// site, and `Serve` provides it for documentation that lacks one.
+//
+//go:embed src/search.html
+var searchPage []byte
+
+// searchPageName is the path of the search page at the documentation root.
+const searchPageName = "search.html"
+
+// snippetRadius is the number of bytes of context shown on either side of
+// the first match in a search result snippet.
+const snippetRadius = 60
+
+// SearchResult is a single match returned by `Search`.
+type SearchResult struct {
+	// Path is the path of the documentation file within the assets.
+	Path string `json:"path"`
+	// URL is the address of the page that shows the file, relative to the
+	// documentation root where the search page is, such as
+	// "guide/setup.html" for "guide/setup.md"; see `ShowPage`.
+	URL string `json:"url"`
+	// Anchor is the anchor of the matching section, suitable for `ShowAt`.
+	// It is empty for text that comes before the first heading of a file.
+	Anchor string `json:"anchor"`
+	// Title is the heading of the matching section.
+	Title string `json:"title"`
+	// Snippet is an excerpt of the section text around the first match.
+	Snippet string `json:"snippet"`
+	// Matches are the positions of the query's words in Snippet, in order
+	// and without overlaps, for highlighting. It is empty if the section
+	// matched only in its title.
+	Matches []MatchRange `json:"matches"`
+	// Score ranks the relevance of the result; higher is better.
+	Score float64 `json:"score"`
+}
+
+// MatchRange is the position of a match in a `SearchResult` snippet, as
+// byte offsets: Snippet[Start:End] is the matched text. Both offsets fall
+// on UTF-8 character boundaries.
+type MatchRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Search performs a case-insensitive full-text search of the documentation
+// and returns the matching sections, most relevant first. Each markdown or
+// HTML file is split into sections at its headings, and a section matches if
+// it contains any of the words in query. Matches in a heading and matches of
+// the whole query as a phrase rank higher. An empty query returns no results.
+//
+// Example:
+//
+//	results, err := helpService.Search("reset password")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if len(results) > 0 {
+//		helpService.ShowAt(results[0].Anchor)
+//	}
+func (s *Service) Search(query string) ([]SearchResult, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	phrase := strings.Join(terms, " ")
+
+	index, err := s.contentIndex(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	dir := s.contentDir()
+	s.mu.Unlock()
+
+	var results []SearchResult
+	for _, p := range index.paths {
+		for _, sec := range index.sections[p] {
+			score := 0.0
+			for _, term := range terms {
+				score += float64(countFold(sec.text, term))
+				score += 5 * float64(countFold(sec.title, term))
+			}
+			if score == 0 {
+				continue
+			}
+			if len(terms) > 1 && (indexFold(sec.text, phrase) >= 0 || indexFold(sec.title, phrase) >= 0) {
+				score += 10
+			}
+			excerpt := snippet(sec.text, terms)
+			results = append(results, SearchResult{
+				Path:    p,
+				URL:     searchURL(dir, p),
+				Anchor:  sec.anchor,
+				Title:   sec.title,
+				Snippet: excerpt,
+				Matches: snippetMatches(excerpt, terms),
+				Score:   score,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results, nil
+}
+
+// searchURL returns the URL, relative to the documentation root, of the
+// page that shows the file p of the doc set and locale in dir.
+func searchURL(dir, p string) string {
+	if page := sitePath(path.Join(dir, p)); page != "" {
+		return page
+	}
+	return "./"
+}
+
+// ShowSearch opens the help window at the built-in search page, with query
+// filled in and its results listed. The page searches with `Search`, through
+// the application's bindings for the help service or the `Serve` endpoint,
+// and each result links to its section. The page is part of the embedded
+// documentation and is provided by `Serve`; for other sources it must be
+// present at the documentation root as "search.html", or an error is
+// returned. An empty query opens the page without searching.
+//
+// Example:
+//
+//	err := helpService.ShowSearch("reset password")
+//	if err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) ShowSearch(query string) error {
+	if !s.hasSearchPage() {
+		return fmt.Errorf("help: search page not available; add %s to the documentation or use Serve", searchPageName)
+	}
+	link := s.baseURL() + searchPageName
+	if query = strings.TrimSpace(query); query != "" {
+		link += "?q=" + url.QueryEscape(query)
+	}
+	if err := s.openURL(context.Background(), link); err != nil {
+		return err
+	}
+	s.emitShow("search", "")
+	return nil
+}
+
+// hasSearchPage reports whether the search page can be opened: the
+// documentation server provides it, as it does when started for
+// `TargetBrowser`, a remote source is assumed to have it, and other sources
+// must contain it.
+func (s *Service) hasSearchPage() bool {
+	s.mu.Lock()
+	serving := s.server != nil
+	s.mu.Unlock()
+	assets := s.rootAssets()
+	if _, remote := assets.(*httpFS); serving || remote || s.inBrowser() {
+		return true
+	}
+	_, err := fs.Stat(assets, searchPageName)
+	return err == nil
+}
+
+// snippet returns an excerpt of text around the first occurrence of any of
+// terms, trimmed to word boundaries and marked with ellipses where cut.
+func snippet(text string, terms []string) string {
+	at := -1
+	for _, term := range terms {
+		if i := indexFold(text, term); i >= 0 && (at < 0 || i < at) {
+			at = i
+		}
+	}
+	if at < 0 {
+		at = 0
+	}
+
+	start := max(at-snippetRadius, 0)
+	end := min(at+snippetRadius, len(text))
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	if start > 0 {
+		if i := strings.IndexByte(text[start:at], ' '); i >= 0 {
+			start += i + 1
+		}
+	}
+	if end < len(text) {
+		if i := strings.LastIndexByte(text[at:end], ' '); i > 0 {
+			end = at + i
+		}
+	}
+
+	excerpt := text[start:end]
+	if start > 0 {
+		excerpt = "…" + excerpt
+	}
+	if end < len(text) {
+		excerpt += "…"
+	}
+	return excerpt
+}
+
+// snippetMatches returns the positions of every case-insensitive
+// occurrence of terms in excerpt, sorted, with overlapping matches merged.
+func snippetMatches(excerpt string, terms []string) []MatchRange {
+	var matches []MatchRange
+	for _, term := range terms {
+		for at := 0; at < len(excerpt); {
+			i, size := matchFold(excerpt[at:], term)
+			if i < 0 {
+				break
+			}
+			matches = append(matches, MatchRange{Start: at + i, End: at + i + size})
+			at += i + size
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+	merged := matches[:0]
+	for _, m := range matches {
+		if n := len(merged); n > 0 && m.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, m.End)
+			continue
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+// countFold returns the number of non-overlapping case-insensitive
+// occurrences of substr in s.
+func countFold(s, substr string) int {
+	n := 0
+	for {
+		i, size := matchFold(s, substr)
+		if i < 0 {
+			return n
+		}
+		n++
+		s = s[i+size:]
+	}
+}
+
+// indexFold returns the byte index of the first case-insensitive occurrence
+// of substr in s, or -1 if there is none.
+func indexFold(s, substr string) int {
+	i, _ := matchFold(s, substr)
+	return i
+}
+
+// matchFold finds the first case-insensitive occurrence of substr in s and
+// returns its byte index and byte length within s, or -1 if there is none.
+// Both values fall on UTF-8 rune boundaries of s.
+func matchFold(s, substr string) (int, int) {
+	if substr == "" {
+		return -1, 0
+	}
+	for i := 0; i < len(s); {
+		if size, ok := prefixFold(s[i:], substr); ok {
+			return i, size
+		}
+		_, width := utf8.DecodeRuneInString(s[i:])
+		i += width
+	}
+	return -1, 0
+}
+
+// prefixFold reports whether s begins with prefix, ignoring case, and returns
+// the byte length of the matching prefix of s.
+func prefixFold(s, prefix string) (int, bool) {
+	n := 0
+	for _, want := range prefix {
+		if n >= len(s) {
+			return 0, false
+		}
+		got, width := utf8.DecodeRuneInString(s[n:])
+		if got != want && unicode.ToLower(got) != unicode.ToLower(want) {
+			return 0, false
+		}
+		n += width
+	}
+	return n, true
+}
@@ -0,0 +1,145 @@
+package help
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var searchDocs = fstest.MapFS{
+	"account.md": {Data: []byte("# Account\n\nManage your profile.\n\n" +
+		"## Reset Password\n\nTo reset your password, open Settings and choose Reset.\n")},
+	"billing.html": {Data: []byte(`<html><head><title>Password</title></head><body>` +
+		`<h1 id="billing">Billing</h1><p>Invoices are emailed monthly.</p>` +
+		`<h2 id="refunds">Refunds</h2><p>Refunds never require your password.</p></body></html>`)},
+}
+
+func TestSearch(t *testing.T) {
+	s, err := New(Options{Assets: searchDocs})
+	assert.NoError(t, err)
+
+	results, err := s.Search("reset PASSWORD")
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	assert.Equal(t, "account.md", results[0].Path)
+	assert.Equal(t, "account.html", results[0].URL)
+	assert.Equal(t, "reset-password", results[0].Anchor)
+	assert.Equal(t, "Reset Password", results[0].Title)
+	assert.Contains(t, results[0].Snippet, "reset your password")
+	for _, m := range results[0].Matches {
+		assert.Contains(t, []string{"reset", "Reset", "password"}, results[0].Snippet[m.Start:m.End])
+	}
+	assert.Len(t, results[0].Matches, 3)
+
+	assert.Equal(t, "billing.html", results[1].Path)
+	assert.Equal(t, "billing.html", results[1].URL)
+	assert.Equal(t, "refunds", results[1].Anchor)
+	assert.Greater(t, results[0].Score, results[1].Score)
+}
+
+func TestSearch_NoMatches(t *testing.T) {
+	s, err := New(Options{Assets: searchDocs})
+	assert.NoError(t, err)
+
+	results, err := s.Search("kubernetes")
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	results, err = s.Search("   ")
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestSearchURL(t *testing.T) {
+	assert.Equal(t, "./", searchURL("", "index.md"))
+	assert.Equal(t, "guide/", searchURL("", "guide/index.md"))
+	assert.Equal(t, "de/guide/setup.html", searchURL("de", "guide/setup.md"))
+	assert.Equal(t, "faq.html", searchURL("", "faq.html"))
+}
+
+func TestSnippet(t *testing.T) {
+	text := strings.Repeat("lorem ipsum ", 20) + "needle " + strings.Repeat("dolor sit ", 20)
+	got := snippet(text, []string{"NEEDLE"})
+	assert.True(t, strings.HasPrefix(got, "…"))
+	assert.True(t, strings.HasSuffix(got, "…"))
+	assert.Contains(t, got, "needle")
+	assert.Less(t, len(got), len(text))
+
+	assert.Equal(t, "short text", snippet("short text", []string{"text"}))
+}
+
+func TestSnippetMatches(t *testing.T) {
+	excerpt := "…Größe and GRÖSSE: größer"
+	matches := snippetMatches(excerpt, []string{"größe", "ÖSSE", "ößer"})
+	assert.Equal(t, []MatchRange{
+		{Start: len("…"), End: len("…Größe")},
+		{Start: len("…Größe and GR"), End: len("…Größe and GRÖSSE")},
+		{Start: len("…Größe and GRÖSSE: "), End: len("…Größe and GRÖSSE: größer")},
+	}, matches)
+	for _, m := range matches {
+		assert.True(t, utf8.ValidString(excerpt[m.Start:m.End]))
+	}
+
+	assert.Empty(t, snippetMatches("no match", []string{"needle"}))
+}
+
+func TestMatchFold(t *testing.T) {
+	i, size := matchFold("Ünïcödé HÉADINGS", "héadings")
+	assert.Equal(t, len("Ünïcödé "), i)
+	assert.Equal(t, len("HÉADINGS"), size)
+
+	assert.Equal(t, 3, countFold("Go go GO", "go"))
+	assert.Equal(t, 0, countFold("", "go"))
+	assert.Equal(t, -1, indexFold("abc", "d"))
+}
+
+func TestShowSearch(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.md":    {Data: []byte("# Welcome\n")},
+		"search.html": {Data: []byte("<html></html>")},
+	}
+	s, _, mockDisplay := setupService(t, Options{Assets: docs})
+
+	assert.NoError(t, s.ShowSearch("reset password"))
+	assert.Equal(t, "/search.html?q=reset+password", mockDisplay.Options["URL"])
+
+	assert.NoError(t, s.ShowSearch(" "))
+	assert.Equal(t, "/search.html", mockDisplay.Options["URL"])
+}
+
+func TestShowSearch_NoPage(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	assert.Error(t, s.ShowSearch("anchor"))
+	assert.False(t, mockDisplay.OpenCalled)
+
+	// The documentation server provides the page.
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+	assert.NoError(t, s.ShowSearch("anchor"))
+	assert.Equal(t, url+"search.html?q=anchor", mockDisplay.Options["URL"])
+	assert.Contains(t, get(t, url+"search.html"), `id="search-query"`)
+}
+
+func TestServe_SearchJSON(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: localeDocs, Locale: "de"})
+
+	rec := serveRequest(s, "/search.json?q=willkommen", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var results []SearchResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &results))
+	assert.Len(t, results, 1)
+	assert.Equal(t, "index.md", results[0].Path)
+	assert.Equal(t, "de/", results[0].URL)
+	assert.Equal(t, "willkommen", results[0].Anchor)
+
+	rec = serveRequest(s, "/search.json", "")
+	assert.Equal(t, "[]\n", rec.Body.String())
+}
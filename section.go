@@ -0,0 +1,100 @@
+package help
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var (
+	// htmlHeadingOpenPattern matches the opening tag of any HTML heading,
+	// capturing its level.
+	htmlHeadingOpenPattern = regexp.MustCompile(`(?i)<h([1-6])\b`)
+	// htmlContentEndPattern matches the end of the element that holds the
+	// content of a generated page.
+	htmlContentEndPattern = regexp.MustCompile(`(?i)</(article|main|body)\s*>`)
+)
+
+// SectionHTML returns the HTML of the section of the documentation that
+// anchor identifies: its heading and everything after it up to the next
+// heading of the same or a higher level, or the end of the page's content.
+// Anchors are accepted as by `ShowAt`, including a `page#anchor` form, and
+// markdown is rendered as by `RenderPage`. It suits inline help popovers,
+// which need one section rather than the whole page. If no heading has the
+// anchor, the error wraps `ErrAnchorNotFound`.
+//
+// Example:
+//
+//	snippet, err := helpService.SectionHTML("billing-cycle")
+//	if errors.Is(err, help.ErrAnchorNotFound) {
+//		snippet = "<p>No help for this field yet.</p>"
+//	}
+//	popover.SetHTML(snippet)
+func (s *Service) SectionHTML(anchor string) (string, error) {
+	target := s.resolveAnchor(anchor)
+	page, fragment, ok := splitTarget(target)
+	notFound := fmt.Errorf("help: anchor %q not found: %w", anchor, ErrAnchorNotFound)
+	if fragment == "" {
+		return "", notFound
+	}
+
+	var files []string
+	if ok && page != "" {
+		if _, err := cleanPath(page); err != nil {
+			return "", err
+		}
+		file, found := findPage(s.currentAssets(), page)
+		if !found {
+			return "", fmt.Errorf("help: page %q not found: %w", page, ErrNotFound)
+		}
+		files = []string{file}
+	} else {
+		index, err := s.contentIndex(context.Background())
+		if err != nil {
+			return "", err
+		}
+		for _, p := range index.paths {
+			for _, sec := range index.sections[p] {
+				if sec.level > 0 && sec.anchor == fragment {
+					files = append(files, p)
+					break
+				}
+			}
+		}
+	}
+
+	for _, file := range files {
+		page, err := s.RenderPage(file)
+		if err != nil {
+			return "", err
+		}
+		if section, ok := extractSection(page, fragment); ok {
+			return section, nil
+		}
+	}
+	return "", notFound
+}
+
+// extractSection returns the part of page from the heading with the given
+// ID up to the next heading of the same or a higher level, or the end of
+// the page's content, and reports whether the heading was found.
+func extractSection(page, id string) (string, bool) {
+	for _, m := range htmlHeadingPattern.FindAllStringSubmatchIndex(page, -1) {
+		if page[m[4]:m[5]] != id {
+			continue
+		}
+		level := page[m[2]]
+		end := len(page)
+		if loc := htmlContentEndPattern.FindStringIndex(page[m[1]:]); loc != nil {
+			end = m[1] + loc[0]
+		}
+		for _, next := range htmlHeadingOpenPattern.FindAllStringSubmatchIndex(page[m[1]:end], -1) {
+			if page[m[1]+next[2]] <= level {
+				end = m[1] + next[0]
+				break
+			}
+		}
+		return page[m[0]:end], true
+	}
+	return "", false
+}
@@ -0,0 +1,54 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var sectionDocs = NewMemorySource(map[string]string{
+	"index.md": "# Welcome\n\nIntro.\n\n## Billing\n\nHow billing works.\n\n" +
+		"### Billing Cycle\n\nMonthly.\n\n## Support\n\nContact us.\n",
+	"guide.html": `<html><body><article><h1 id="guide">Guide</h1><p>Start here.</p>` +
+		`<h2 id="setup">Setup</h2><p>Install it.</p><h3>Notes</h3><p>Optional.</p>` +
+		`</article><footer>Footer</footer></body></html>`,
+})
+
+func TestSectionHTML(t *testing.T) {
+	s, err := New(Options{Assets: sectionDocs})
+	assert.NoError(t, err)
+
+	html, err := s.SectionHTML("billing")
+	assert.NoError(t, err)
+	assert.Equal(t, "<h2 id=\"billing\">Billing</h2>\n<p>How billing works.</p>\n"+
+		"<h3 id=\"billing-cycle\">Billing Cycle</h3>\n<p>Monthly.</p>\n", html)
+
+	html, err = s.SectionHTML("#billing-cycle")
+	assert.NoError(t, err)
+	assert.Equal(t, "<h3 id=\"billing-cycle\">Billing Cycle</h3>\n<p>Monthly.</p>\n", html)
+
+	html, err = s.SectionHTML("support")
+	assert.NoError(t, err)
+	assert.Equal(t, "<h2 id=\"support\">Support</h2>\n<p>Contact us.</p>\n", html)
+
+	html, err = s.SectionHTML("guide.html#setup")
+	assert.NoError(t, err)
+	assert.Equal(t, `<h2 id="setup">Setup</h2><p>Install it.</p><h3>Notes</h3><p>Optional.</p>`, html)
+
+	html, err = s.SectionHTML("guide")
+	assert.NoError(t, err)
+	assert.Equal(t, `<h1 id="guide">Guide</h1><p>Start here.</p>`+
+		`<h2 id="setup">Setup</h2><p>Install it.</p><h3>Notes</h3><p>Optional.</p>`, html)
+}
+
+func TestSectionHTML_NotFound(t *testing.T) {
+	s, err := New(Options{Assets: sectionDocs})
+	assert.NoError(t, err)
+
+	for _, anchor := range []string{"missing", "", "index.md#setup"} {
+		_, err = s.SectionHTML(anchor)
+		assert.ErrorIs(t, err, ErrAnchorNotFound, anchor)
+	}
+	_, err = s.SectionHTML("missing.md#billing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,264 @@
+package help
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io/fs"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// shutdownTimeout bounds how long a graceful shutdown of the documentation
+// server waits for in-flight requests.
+const shutdownTimeout = 5 * time.Second
+
+// bindTimeout bounds how long `Serve` waits for the documentation server's
+// listener to be ready.
+const bindTimeout = 5 * time.Second
+
+// Serve starts an HTTP server that serves the documentation assets on addr
+// and returns its base URL. If addr is empty, the server listens on a random
+// free port on the loopback interface. While the server is running, `Show`
+// and `ShowAt` point the help window at it, which gives the webview a real
+// `http://localhost` origin for resolving relative links. The server is shut
+// down gracefully by `Close`, or when the context passed to `ServiceStartup`
+// is cancelled. Responses are compressed when the client accepts it, using
+// pre-compressed ".br" or ".gz" siblings of a file when the assets have them.
+// If the server is already running, Serve returns its base URL rather than
+// starting another, whatever addr is, so that it is safe to call from hooks
+// that may run more than once. It gives up with an error if the listener is
+// not ready within five seconds; use `ServeContext` to choose the limit.
+//
+// Example:
+//
+//	url, err := helpService.Serve("")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println("Serving help at", url)
+func (s *Service) Serve(addr string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), bindTimeout)
+	defer cancel()
+	return s.ServeContext(ctx, addr)
+}
+
+// ServeContext is like `Serve`, but gives up with an error if ctx is
+// cancelled or its deadline passes before the server's listener is ready,
+// such as when binding addr stalls. Once started, the server runs until shut
+// down as described for Serve, whatever becomes of ctx.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	url, err := helpService.ServeContext(ctx, "127.0.0.1:8321")
+func (s *Service) ServeContext(ctx context.Context, addr string) (string, error) {
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+
+	s.mu.Lock()
+	running := s.serveURL
+	s.mu.Unlock()
+	if running != "" {
+		return running, nil
+	}
+
+	var lc net.ListenConfig
+	ln, err := lc.Listen(ctx, "tcp", addr)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if err == nil {
+			ln.Close()
+		}
+		return "", fmt.Errorf("help: serve %s: listener not ready: %w", addr, ctxErr)
+	}
+	if err != nil {
+		return "", fmt.Errorf("help: serve %s: %w", addr, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		// Another call started the server while this one was binding.
+		ln.Close()
+		return s.serveURL, nil
+	}
+	server := &http.Server{
+		Handler:           http.HandlerFunc(s.serveHTTP),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go server.Serve(ln)
+
+	s.server = server
+	s.serveURL = listenURL(ln.Addr())
+	s.serveStop = make(chan struct{})
+	if s.ctx != nil {
+		go func(ctx context.Context, stop <-chan struct{}) {
+			select {
+			case <-ctx.Done():
+				s.stopServing()
+			case <-stop:
+			}
+		}(s.ctx, s.serveStop)
+	}
+	return s.serveURL, nil
+}
+
+// serveHTTP serves a request from the current documentation assets, so that
+// a source switched with `SetSource` takes effect immediately. Every locale
+// is served, each under its own directory. HTML pages are expanded with the
+// template data of the options, and carry their extra CSS and JavaScript
+// and a Content-Security-Policy header. Responses are compressed when the
+// client accepts it; see `serveCompressed`. Paths that would resolve
+// outside the documentation root are rejected. The search page and its
+// "search.json" endpoint are provided too; see `ShowSearch`. Documentation
+// without an index page gets a generated landing page listing its pages.
+func (s *Service) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	css, js, data := s.extraCSS(), s.opts.ExtraJS, s.opts.TemplateData
+	policy := s.opts.ContentSecurityPolicy
+	s.mu.Unlock()
+	if policy == "" {
+		policy = defaultContentSecurityPolicy(css, js)
+	}
+	w = &cspResponseWriter{ResponseWriter: w, policy: policy}
+
+	if name := strings.Trim(r.URL.Path, "/"); name != "" {
+		if _, err := cleanPath(name); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	switch strings.Trim(r.URL.Path, "/") {
+	case "search.json":
+		s.serveSearch(w, r)
+		return
+	case searchPageName:
+		if _, err := fs.Stat(s.rootAssets(), searchPageName); err != nil {
+			http.ServeContent(w, r, searchPageName, time.Time{}, bytes.NewReader(searchPage))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	dir := s.contentDir()
+	s.mu.Unlock()
+	if s.serveAbout(w, strings.Trim(r.URL.Path, "/"), dir, css, js) {
+		return
+	}
+	if s.servedPageHidden(r.URL.Path) {
+		http.NotFound(w, r)
+		return
+	}
+	if strings.Trim(r.URL.Path, "/") == dir && !hasIndexPage(s.currentAssets()) {
+		s.serveLanding(w, css, js)
+		return
+	}
+
+	// The theme on the URL of the help window wins over that of the
+	// service, which pages reached by a link are shown in.
+	theme := r.URL.Query().Get("theme")
+	if theme == "" || checkTheme(theme) != nil {
+		theme = s.theme()
+	}
+	scheme := colorScheme(theme)
+	if s.serveMarkdown(w, strings.Trim(r.URL.Path, "/"), dir, css, js, scheme) {
+		return
+	}
+	fsys := s.rootAssets()
+	if len(css) > 0 || len(js) > 0 || data != nil || scheme != "" {
+		fsys = &injectFS{FS: fsys, css: css, js: js, data: data, scheme: scheme}
+	}
+	serveCompressed(w, r, fsys, http.FileServer(http.FS(fsys)))
+}
+
+// serveSearch answers a search from the search page with the results of
+// `Search` for the "q" query parameter, as JSON.
+func (s *Service) serveSearch(w http.ResponseWriter, r *http.Request) {
+	results, err := s.Search(r.URL.Query().Get("q"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if results == nil {
+		results = []SearchResult{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(results)
+}
+
+// stopServing gracefully shuts down the documentation server, if running.
+func (s *Service) stopServing() error {
+	s.mu.Lock()
+	server := s.server
+	if server != nil {
+		close(s.serveStop)
+	}
+	s.server = nil
+	s.serveURL = ""
+	s.serveStop = nil
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return server.Shutdown(ctx)
+}
+
+// listenURL returns the base URL for a server listening on addr. A server
+// listening on all interfaces is addressed as localhost.
+func listenURL(addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return "http://" + addr.String() + "/"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port) + "/"
+}
+
+// serveMarkdown serves the markdown page that the help window shows at
+// name, a request path in the current doc set and locale, rendered as HTML
+// by `RenderPage`, and reports whether it did. It serves nothing if the
+// documentation has a file of its own at name, or if name refers to no
+// markdown page; see `sitePath`. Markdown files themselves are served as
+// they are.
+func (s *Service) serveMarkdown(w http.ResponseWriter, name, dir string, css, js []string, scheme string) bool {
+	rel, ok := withinDir(name, dir)
+	if !ok || isMarkdown(rel) {
+		return false
+	}
+	fsys := s.currentAssets()
+	if info, err := fs.Stat(fsys, path.Join(".", rel)); err == nil {
+		if !info.IsDir() {
+			return false
+		}
+		if _, err := fs.Stat(fsys, path.Join(rel, "index.html")); err == nil {
+			return false
+		}
+	}
+	file, ok := findPage(fsys, rel)
+	if !ok || !isMarkdown(file) {
+		return false
+	}
+	body, err := s.RenderPage(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return true
+	}
+	data, _ := fs.ReadFile(fsys, file)
+	title := html.EscapeString(pageTitle(file, data))
+	doc := "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>" + title + "</title>\n</head>\n<body>\n<main>\n" + body + "</main>\n</body>\n</html>\n"
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(injectScheme(injectExtras([]byte(doc), css, js), scheme))
+	return true
+}
@@ -0,0 +1,132 @@
+package help
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func get(t *testing.T, url string) string {
+	t.Helper()
+	resp, err := http.Get(url)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestServe(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+	assert.True(t, strings.HasPrefix(url, "http://127.0.0.1:"))
+	assert.True(t, strings.HasSuffix(url, "/"))
+	assert.Contains(t, get(t, url+"index.md"), "## Good Anchor")
+
+	again, err := s.Serve("127.0.0.1:0")
+	assert.NoError(t, err)
+	assert.Equal(t, url, again)
+}
+
+func TestServe_MarkdownPage(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: siteDocs})
+
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+	page := get(t, url+"installation.html")
+	assert.Contains(t, page, "<title>Installation</title>")
+	assert.Contains(t, page, `<h2 id="requirements">Requirements</h2>`)
+	assert.Contains(t, get(t, url+"guide/advanced.html"), `<h1 id="advanced">Advanced</h1>`)
+	assert.Contains(t, get(t, url+"installation.md"), "## Requirements")
+}
+
+func TestServeContext_Cancelled(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.ServeContext(ctx, "")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "listener not ready")
+	assert.Empty(t, s.serveURL)
+
+	url, err := s.ServeContext(context.Background(), "")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+	assert.NotEmpty(t, url)
+}
+
+func TestServe_AddressInUse(t *testing.T) {
+	first, _, _ := setupService(t, Options{Assets: testDocs})
+	url, err := first.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = first.stopServing() })
+
+	second, _, _ := setupService(t, Options{Assets: testDocs})
+	addr := strings.TrimSuffix(strings.TrimPrefix(url, "http://"), "/")
+	_, err = second.Serve(addr)
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "listener not ready")
+}
+
+func TestServe_ShowUsesServedURL(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	err = s.Show()
+	assert.NoError(t, err)
+	assert.Equal(t, url, mockDisplay.Options["URL"])
+
+	err = s.ShowAt("test-anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, url+"#test-anchor", mockDisplay.Options["URL"])
+}
+
+func TestServe_CloseShutsDown(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+
+	err = s.Close()
+	assert.NoError(t, err)
+	_, err = http.Get(url)
+	assert.Error(t, err)
+	assert.Equal(t, "/", s.baseURL())
+}
+
+func TestServe_ContextCancelShutsDown(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, s.ServiceStartup(ctx))
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		return s.baseURL() == "/"
+	}, time.Second, 10*time.Millisecond)
+	_, err = http.Get(url)
+	assert.Error(t, err)
+}
+
+func TestServe_InvalidAddr(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	_, err := s.Serve("not an address")
+	assert.Error(t, err)
+	assert.Equal(t, "/", s.baseURL())
+}
@@ -0,0 +1,63 @@
+package help
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// defaultShortcut is the accelerator `RegisterShortcut` binds when none is
+// given: the conventional help key.
+const defaultShortcut = "F1"
+
+// RegisterShortcut binds a keyboard accelerator, such as "F1" or
+// "CmdOrCtrl+Shift+H", to `Show` through the key bindings of the `wails3`
+// application app, so that pressing it in any of the application's windows
+// opens help. If app is nil, the running application is used, and if the
+// accelerator is empty, "F1" is bound. An error is returned if the
+// accelerator is already bound, compared case-insensitively, so that an
+// existing binding of the host is never replaced. As the key press has no
+// caller to return to, errors from `Show` are logged; see `SetLogger`.
+//
+// Example:
+//
+//	if err := helpService.RegisterShortcut(app, ""); err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) RegisterShortcut(app *application.App, accelerator string) error {
+	if app == nil {
+		app = s.wailsApp()
+	}
+	if app == nil || app.KeyBinding == nil {
+		return fmt.Errorf("help: cannot register shortcut: %w", ErrWailsNotRunning)
+	}
+	return s.bindShortcut(app.KeyBinding, accelerator)
+}
+
+// keyBinder holds the key bindings of a `wails3` application: an
+// `*application.KeyBindingManager`, or a fake in tests.
+type keyBinder interface {
+	Add(accelerator string, callback func(window application.Window))
+	GetAll() []*application.KeyBinding
+}
+
+// bindShortcut binds accelerator, or "F1" if it is empty, to `Show` in
+// bindings, as described for `RegisterShortcut`.
+func (s *Service) bindShortcut(bindings keyBinder, accelerator string) error {
+	accelerator = strings.TrimSpace(accelerator)
+	if accelerator == "" {
+		accelerator = defaultShortcut
+	}
+	for _, binding := range bindings.GetAll() {
+		if strings.EqualFold(binding.Accelerator, accelerator) {
+			return fmt.Errorf("help: shortcut %q is already registered", accelerator)
+		}
+	}
+	bindings.Add(accelerator, func(application.Window) {
+		if err := s.Show(); err != nil {
+			s.logger().Error(err.Error())
+		}
+	})
+	return nil
+}
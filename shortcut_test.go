@@ -0,0 +1,53 @@
+package help
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// fakeKeyBindings is a keyBinder that stands in for the key bindings of a
+// running application.
+type fakeKeyBindings map[string]func(application.Window)
+
+func (f fakeKeyBindings) Add(accelerator string, callback func(window application.Window)) {
+	f[accelerator] = callback
+}
+
+func (f fakeKeyBindings) GetAll() []*application.KeyBinding {
+	var all []*application.KeyBinding
+	for accelerator, callback := range f {
+		all = append(all, &application.KeyBinding{Accelerator: accelerator, Callback: callback})
+	}
+	return all
+}
+
+func TestRegisterShortcut(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	bindings := fakeKeyBindings{}
+
+	assert.NoError(t, s.bindShortcut(bindings, ""))
+	if assert.Contains(t, bindings, "F1") {
+		bindings["F1"](nil)
+	}
+	assert.Equal(t, "help", mockDisplay.WindowName)
+
+	assert.NoError(t, s.bindShortcut(bindings, "CmdOrCtrl+H"))
+	assert.Len(t, bindings, 2)
+}
+
+func TestRegisterShortcut_AlreadyRegistered(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	bindings := fakeKeyBindings{"f1": func(application.Window) {}}
+
+	err := s.bindShortcut(bindings, "F1")
+	assert.ErrorContains(t, err, `shortcut "F1" is already registered`)
+	assert.Len(t, bindings, 1)
+}
+
+func TestRegisterShortcut_NoApp(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	assert.ErrorIs(t, s.RegisterShortcut(nil, "F1"), ErrWailsNotRunning)
+	assert.ErrorIs(t, s.RegisterShortcut(&application.App{}, "F1"), ErrWailsNotRunning)
+}
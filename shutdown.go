@@ -0,0 +1,59 @@
+package help
+
+import "context"
+
+// Shutdown releases everything the service holds, for a clean exit: it
+// stops the watchers started by `Watch`, closes every help window, and
+// shuts down the documentation server started by `Serve`, as `CloseAll`
+// does. It returns ctx's error if ctx is done before the cleanup finishes.
+// Applications do not usually need to call it: `ServiceShutdown` calls it
+// when a `wails3` application or core runtime stops the service.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := helpService.Shutdown(ctx); err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.stopWatching()
+	return withContext(ctx, s.CloseAll)
+}
+
+// ServiceShutdown is a lifecycle method that is called by the application
+// when it shuts down. It releases the service's resources; see `Shutdown`.
+func (s *Service) ServiceShutdown() error {
+	return s.Shutdown(context.Background())
+}
+
+// trackWatch returns a context derived from ctx that `Shutdown` cancels,
+// for a call to `Watch`, and a function that stops tracking it.
+func (s *Service) trackWatch(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.watchCancels == nil {
+		s.watchCancels = make(map[int]context.CancelFunc)
+	}
+	id := s.watchID
+	s.watchID++
+	s.watchCancels[id] = cancel
+	return ctx, func() {
+		s.mu.Lock()
+		delete(s.watchCancels, id)
+		s.mu.Unlock()
+		cancel()
+	}
+}
+
+// stopWatching cancels every `Watch` call in progress.
+func (s *Service) stopWatching() {
+	s.mu.Lock()
+	cancels := s.watchCancels
+	s.watchCancels = nil
+	s.mu.Unlock()
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
@@ -0,0 +1,66 @@
+package help
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdown(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "index.md"), []byte("# Welcome\n"), 0o644))
+	s, err := New(Options{Source: dir})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+	var closed int
+	s.OnClose(func() { closed++ })
+
+	_, err = s.Serve("")
+	assert.NoError(t, err)
+	assert.NoError(t, s.Show())
+	done := make(chan error, 1)
+	go func() { done <- s.Watch(context.Background()) }()
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.watchCancels) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, s.Shutdown(context.Background()))
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch did not stop on Shutdown")
+	}
+	s.mu.Lock()
+	assert.Empty(t, s.serveURL)
+	assert.Empty(t, s.windows)
+	s.mu.Unlock()
+	assert.Equal(t, 1, closed)
+	if created := windows.windows(); assert.Len(t, created, 1) {
+		_, _, isClosed := created[0].state()
+		assert.True(t, isClosed)
+	}
+}
+
+func TestServiceShutdown(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	assert.NoError(t, s.Show())
+
+	assert.NoError(t, s.ServiceShutdown())
+	assert.True(t, mockDisplay.CloseCalled)
+}
+
+func TestShutdown_Context(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, s.Shutdown(ctx), context.Canceled)
+}
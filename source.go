@@ -0,0 +1,404 @@
+package help
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing/fstest"
+	"time"
+)
+
+// resolveAssets builds the documentation filesystem described by opts. A
+// provided `Assets` filesystem takes precedence, then an overlay of the
+// `Sources` list, and finally the single `Source`.
+func resolveAssets(opts Options) (fs.FS, error) {
+	if opts.Assets != nil {
+		return opts.Assets, nil
+	}
+	if len(opts.Sources) > 0 {
+		overlay := &overlayFS{}
+		for _, source := range opts.Sources {
+			layer, err := resolveSource(source, opts)
+			if err != nil {
+				return nil, err
+			}
+			overlay.layers = append(overlay.layers, layer)
+		}
+		return overlay, nil
+	}
+	return resolveSource(opts.Source, opts)
+}
+
+// resolveSource builds the filesystem for a single source: a remote URL,
+// fetched with the `HTTPClient` of opts and cached for its `CacheTTL`, a
+// zip archive, a local directory, or the default
+// "mkdocs" content when source is empty or "mkdocs": the embedded docs, or
+// those set with `SetDefaultAssets`.
+func resolveSource(source string, opts Options) (fs.FS, error) {
+	if isRemoteSource(source) {
+		return newHTTPFS(source, opts.HTTPClient, opts.CacheTTL)
+	}
+	if isZipSource(source) {
+		return newZipFS(source)
+	}
+	if source != "" && source != "mkdocs" {
+		return os.DirFS(source), nil
+	}
+	return defaultSource()
+}
+
+// NewMemorySource returns a documentation filesystem held in memory, built
+// from files that maps each path, such as "guide/install.md", to its
+// content. Directories are created as needed. It can be passed as
+// `Options.Assets`, which makes it convenient for tests of a help
+// integration that should not touch the disk.
+//
+// Example:
+//
+//	helpService, err := help.New(help.Options{
+//		Assets: help.NewMemorySource(map[string]string{
+//			"index.md": "# Welcome\n\n## Getting Started\n",
+//		}),
+//	})
+func NewMemorySource(files map[string]string) fs.FS {
+	fsys := make(fstest.MapFS, len(files))
+	for name, content := range files {
+		fsys[strings.TrimPrefix(path.Clean("/"+name), "/")] = &fstest.MapFile{
+			Data: []byte(content),
+			Mode: 0o444,
+		}
+	}
+	return fsys
+}
+
+// Assets returns the documentation filesystem resolved from the options:
+// the embedded docs, a local directory, a remote source, a layered set of
+// `Sources`, or the provided `Assets`. It includes every locale. This lets
+// the documentation be mounted in an HTTP server or other pipeline of the
+// application's own, without reimplementing how sources are resolved.
+//
+// Example:
+//
+//	mux.Handle("/help/", http.StripPrefix("/help/",
+//		http.FileServer(http.FS(helpService.Assets()))))
+func (s *Service) Assets() fs.FS {
+	return s.rootAssets()
+}
+
+// Kinds of documentation source reported by `SourceInfo`.
+const (
+	// SourceEmbedded is the "mkdocs" documentation embedded in the binary.
+	SourceEmbedded = "embedded"
+	// SourceDir is a local directory.
+	SourceDir = "dir"
+	// SourceZip is a zip archive.
+	SourceZip = "zip"
+	// SourceFS is a filesystem provided as `Options.Assets`.
+	SourceFS = "fs"
+	// SourceHTTP is documentation hosted on a web server.
+	SourceHTTP = "http"
+	// SourceLayered is a set of `Options.Sources` layered over each other.
+	SourceLayered = "layered"
+)
+
+// SourceInfo describes the documentation source the service resolved, for
+// diagnostics.
+type SourceInfo struct {
+	// Kind is the kind of source: `SourceEmbedded`, `SourceDir`,
+	// `SourceZip`, `SourceFS`, `SourceHTTP`, or `SourceLayered`.
+	Kind string `json:"kind"`
+	// Location is the absolute path of a directory or zip archive, the URL of a web
+	// server, or the layered sources separated by commas. It is empty for
+	// embedded and `Options.Assets` sources.
+	Location string `json:"location"`
+	// Readable reports whether the source could be read: the root of a
+	// filesystem or directory could be listed, or a web server responded.
+	Readable bool `json:"readable"`
+	// Fallback reports whether `Options.FallbackAssets` are in use because
+	// a remote source could not be reached. Readable then describes the
+	// fallback assets.
+	Fallback bool `json:"fallback"`
+}
+
+// SourceInfo reports which documentation source the service resolved and
+// whether it can be read, so that blank help can be diagnosed without a
+// debugger. For a remote source, it checks that the server is reachable.
+// `ServiceStartup` logs the kind and location of the source.
+//
+// Example:
+//
+//	info := helpService.SourceInfo()
+//	log.Printf("help source: %s %s (readable: %t)", info.Kind, info.Location, info.Readable)
+func (s *Service) SourceInfo() SourceInfo {
+	info := s.sourceInfo()
+	info.Fallback = s.isOffline()
+	assets := s.rootAssets()
+	if remote, ok := assets.(*httpFS); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), sourceInfoTimeout)
+		defer cancel()
+		info.Readable = remote.ping(ctx) == nil
+		return info
+	}
+	_, err := fs.ReadDir(assets, ".")
+	info.Readable = err == nil
+	return info
+}
+
+// sourceInfoTimeout bounds how long `SourceInfo` waits for a remote source.
+const sourceInfoTimeout = 5 * time.Second
+
+// sourceInfo returns the kind and location of the documentation source.
+func (s *Service) sourceInfo() SourceInfo {
+	s.mu.Lock()
+	opts := s.opts
+	s.mu.Unlock()
+	switch {
+	case opts.Assets != nil:
+		return SourceInfo{Kind: SourceFS}
+	case len(opts.Sources) > 0:
+		return SourceInfo{Kind: SourceLayered, Location: strings.Join(opts.Sources, ", ")}
+	case isRemoteSource(opts.Source):
+		return SourceInfo{Kind: SourceHTTP, Location: opts.Source}
+	case opts.Source == "mkdocs":
+		return SourceInfo{Kind: SourceEmbedded}
+	}
+	location, err := filepath.Abs(opts.Source)
+	if err != nil {
+		location = opts.Source
+	}
+	if isZipSource(opts.Source) {
+		return SourceInfo{Kind: SourceZip, Location: location}
+	}
+	return SourceInfo{Kind: SourceDir, Location: location}
+}
+
+// SetSource switches the documentation shown by the service at runtime. Only
+// the `Source`, `Sources`, and `Assets` fields of opts are used; they are
+// resolved in the same way as in `New`, and the other settings of the
+// service are kept. An error is returned, and the current documentation
+// kept, if a `Source` directory or zip archive does not exist. Open help
+// windows, including one opened through the `Display` service, are
+// reloaded to show the new content; see `reloadWindows`. A zip archive of
+// the previous source is closed.
+//
+// Example:
+//
+//	err := helpService.SetSource(help.Options{Source: "docs/billing"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (s *Service) SetSource(opts Options) error {
+	if opts.Source == "" {
+		opts.Source = "mkdocs"
+	}
+	if opts.Assets == nil && len(opts.Sources) == 0 && opts.Source != "mkdocs" && !isRemoteSource(opts.Source) {
+		info, err := os.Stat(opts.Source)
+		if err != nil {
+			return fmt.Errorf("help: invalid source %q: %w", opts.Source, err)
+		}
+		if !info.IsDir() && !isZipSource(opts.Source) {
+			return fmt.Errorf("help: invalid source %q: not a directory", opts.Source)
+		}
+	}
+	assets, err := resolveAssets(opts)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	previous, previousURL := s.assets, s.docURL()
+	s.assets = assets
+	s.locale = pickLocale(localize(assets, s.opts.DocSet), s.opts.Locale, s.opts.DefaultLocale)
+	s.opts.Source = opts.Source
+	s.opts.Sources = opts.Sources
+	s.opts.Assets = opts.Assets
+	s.offline = nil
+	s.sourceChecked = false
+	s.mu.Unlock()
+	s.invalidateIndex()
+	s.warnLocale()
+	if err := closeAssets(previous); err != nil {
+		s.logger().Error(fmt.Sprintf("help: closing previous source: %v", err))
+	}
+
+	return s.reloadWindows(previousURL)
+}
+
+// baseURL returns the URL the help window opens for the documentation root:
+// the URL of the server started by `Serve` while it is running, the remote
+// URL for an HTTP source, or `Options.BasePath` for content served by the
+// application.
+func (s *Service) baseURL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.baseURLLocked()
+}
+
+// baseURLLocked is like `baseURL`, but must be called with mu held.
+func (s *Service) baseURLLocked() string {
+	if s.serveURL != "" {
+		return s.serveURL
+	}
+	if h, ok := s.assets.(*httpFS); ok {
+		return h.base.String()
+	}
+	return s.opts.BasePath
+}
+
+// checkBasePath returns an error if path is not a valid `Options.BasePath`:
+// an absolute path, or a URL with a scheme.
+func checkBasePath(path string) error {
+	if strings.HasPrefix(path, "/") {
+		return nil
+	}
+	if u, err := url.Parse(path); err == nil && u.Scheme != "" {
+		return nil
+	}
+	return fmt.Errorf("help: invalid base path %q: must start with a scheme or \"/\"", path)
+}
+
+// LinkFor returns the URL that `ShowAt` navigates to for anchor, suitable
+// for sharing as a deep link. It includes the base URL of the server started
+// by `Serve` or of a remote source, the locale, and the theme. For content
+// served by the application itself, it is of the form "/#anchor", or starts
+// with `Options.BasePath` if that is set. An empty
+// anchor links to the documentation root. As with `ShowAt`, an error is
+// returned if the anchor does not exist.
+//
+// Example:
+//
+//	link, err := helpService.LinkFor("getting-started")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println("See", link)
+func (s *Service) LinkFor(anchor string) (string, error) {
+	anchor = s.resolveAnchor(anchor)
+	if err := s.checkAnchor(anchor); err != nil {
+		return "", err
+	}
+	return s.pageURL(anchor), nil
+}
+
+// docURL returns the URL of the current doc set and locale: the base URL
+// followed by their directory, if any. It must be called with mu held.
+func (s *Service) docURL() string {
+	url := s.baseURLLocked()
+	if dir := s.contentDir(); dir != "" {
+		url += dir + "/"
+	}
+	return url
+}
+
+// reloadWindows points the open help windows at the current documentation
+// after the source, locale, or doc set has changed. previous is the URL of
+// the documentation they were showing, as `docURL` returned it before the
+// change. The most recent help window opens at the current anchor, through
+// the `Display` service if that opened it, and every other window tracked
+// by the `wails3` fallback moves to the same page of the new documentation.
+// A window whose URL is not within previous is reloaded as it is.
+func (s *Service) reloadWindows(previous string) error {
+	url := s.pageURL(s.CurrentAnchor())
+	s.mu.Lock()
+	current, displayOpen := s.window, s.displayOpen
+	root := s.docURL()
+	for key, window := range s.windows {
+		target := url
+		if window != current {
+			rest, ok := strings.CutPrefix(s.urls[key], previous)
+			if !ok {
+				window.Reload()
+				continue
+			}
+			target = root + rest
+		}
+		s.urls[key] = target
+		window.SetURL(target)
+	}
+	s.mu.Unlock()
+
+	if displayOpen && s.display != nil && !s.inBrowser() {
+		return s.openURL(context.Background(), url)
+	}
+	return nil
+}
+
+// targetPage returns the path, relative to the current doc set and locale,
+// of the page the help window opens for a navigation target with the given
+// page and fragment: the site path of the documentation file that page
+// refers to, or, if page is empty, of the file that defines the fragment;
+// see `sitePath` and `anchorPage`. The page is returned as given if it
+// cannot be resolved, as on a remote source, whose pages cannot be listed.
+func (s *Service) targetPage(page, fragment string) string {
+	fsys := s.currentAssets()
+	if _, ok := fsys.(*httpFS); ok {
+		return page
+	}
+	if strings.Trim(page, "/") == "" {
+		if fragment == "" {
+			return page
+		}
+		if file, ok := s.anchorPage(fragment); ok {
+			return sitePath(file)
+		}
+		return page
+	}
+	if file, ok := findPage(fsys, page); ok {
+		return sitePath(file)
+	}
+	return page
+}
+
+// normalizeAnchor trims surrounding whitespace and leading `#` characters
+// from an anchor given to `ShowAt` and related methods.
+func normalizeAnchor(anchor string) string {
+	return strings.TrimLeft(strings.TrimSpace(anchor), "#")
+}
+
+// resolveAnchor normalizes anchor and, if it is a deprecated anchor listed in
+// `Options.AnchorAliases`, returns its current target instead, logging a
+// deprecation notice. The "about" anchor is pointed at the page generated
+// from `Options.AppInfo`, if there is one.
+func (s *Service) resolveAnchor(anchor string) string {
+	anchor = normalizeAnchor(anchor)
+	s.mu.Lock()
+	target, ok := s.opts.AnchorAliases[anchor]
+	s.mu.Unlock()
+	if !ok {
+		if anchor == aboutAnchor {
+			if about, ok := s.aboutTarget(); ok {
+				return about
+			}
+		}
+		return anchor
+	}
+	target = normalizeAnchor(target)
+	s.logger().Info(fmt.Sprintf("help: anchor %q is deprecated, use %q", anchor, target))
+	return target
+}
+
+// pageURL returns the URL of the help window for anchor, or for the
+// documentation root if anchor is empty, within the current doc set and
+// locale. An anchor of the form `page#anchor` opens that page, and a bare
+// anchor the page that defines it; see `targetPage`. The theme, if any, is
+// passed as a `theme` query parameter.
+func (s *Service) pageURL(anchor string) string {
+	page, fragment, _ := splitTarget(anchor)
+	page = s.targetPage(page, fragment)
+	s.mu.Lock()
+	url := s.docURL()
+	s.mu.Unlock()
+	url += page
+	if theme := s.theme(); theme != "" {
+		url += "?theme=" + theme
+	}
+	if fragment != "" {
+		url += "#" + fragment
+	}
+	return url
+}
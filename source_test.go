@@ -0,0 +1,231 @@
+package help
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSource(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	docs := fstest.MapFS{
+		"index.md": {Data: []byte("# Billing\n")},
+	}
+	err := s.SetSource(Options{Assets: docs})
+	assert.NoError(t, err)
+
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"billing"}, anchors)
+}
+
+func TestSetSource_ReloadsDisplay(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	assert.NoError(t, s.ShowAt("good-anchor"))
+
+	mockDisplay.OpenCalled = false
+	assert.NoError(t, s.SetSource(Options{Assets: testDocs}))
+	assert.True(t, mockDisplay.OpenCalled)
+	assert.Equal(t, "/#good-anchor", mockDisplay.Options["URL"])
+}
+
+func TestSetSource_ReloadsWindows(t *testing.T) {
+	s, err := New(Options{Assets: testDocs, Locale: "de", WindowReuse: WindowReusePerAnchor})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+	assert.NoError(t, s.ShowAt("good-anchor"))
+	assert.NoError(t, s.ShowAt("any-anchor"))
+
+	// The new source has the requested locale, which the old one lacked.
+	docs := NewMemorySource(map[string]string{
+		"de/index.md": "# Hilfe\n\n## Good Anchor\n\n## Any Anchor\n",
+	})
+	assert.NoError(t, s.SetSource(Options{Assets: docs}))
+	if assert.Len(t, windows.windows(), 2) {
+		first, _, _ := windows.windows()[0].state()
+		assert.Equal(t, "/de/#good-anchor", first)
+		second, _, _ := windows.windows()[1].state()
+		assert.Equal(t, "/de/#any-anchor", second)
+	}
+}
+
+func TestSetSource_Directory(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs, WindowTitle: "Docs"})
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "index.md"), []byte("# Reports\n"), 0o644)
+	assert.NoError(t, err)
+
+	err = s.SetSource(Options{Source: dir})
+	assert.NoError(t, err)
+	assert.Equal(t, dir, s.opts.Source)
+	assert.Nil(t, s.opts.Assets)
+	assert.Equal(t, "Docs", s.opts.WindowTitle)
+
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reports"}, anchors)
+}
+
+func TestSetSource_Invalid(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	err := s.SetSource(Options{Source: filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "index.md")
+	assert.NoError(t, os.WriteFile(file, []byte("# Index\n"), 0o644))
+	err = s.SetSource(Options{Source: file})
+	assert.Error(t, err)
+
+	// The original documentation is kept after a failed switch.
+	assert.Equal(t, testDocs, s.assets)
+}
+
+func TestSetSource_Embedded(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs})
+
+	err := s.SetSource(Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, "mkdocs", s.opts.Source)
+	assert.NotEqual(t, testDocs, s.assets)
+}
+
+func TestAssets(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	assert.Equal(t, testDocs, s.Assets())
+
+	dir := t.TempDir()
+	err = s.SetSource(Options{Source: dir})
+	assert.NoError(t, err)
+	assert.Equal(t, os.DirFS(dir), s.Assets())
+}
+
+func TestNewMemorySource(t *testing.T) {
+	docs := NewMemorySource(map[string]string{
+		"index.md":          "# Welcome\n\n## Getting Started\n",
+		"/guide/install.md": "# Install\n\nRun the installer.\n",
+	})
+	assert.NoError(t, fstest.TestFS(docs, "index.md", "guide/install.md"))
+
+	s, err := New(Options{Assets: docs})
+	assert.NoError(t, err)
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"getting-started", "install", "welcome"}, anchors)
+
+	results, err := s.Search("installer")
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "guide/install.md", results[0].Path)
+	}
+}
+
+func TestLinkFor(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+
+	link, err := s.LinkFor("#test-anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, "/#test-anchor", link)
+
+	link, err = s.LinkFor("")
+	assert.NoError(t, err)
+	assert.Equal(t, "/", link)
+
+	_, err = s.LinkFor("missing-anchor")
+	assert.Error(t, err)
+}
+
+func TestLinkFor_BasePath(t *testing.T) {
+	tests := map[string]string{
+		"":              "/#test-anchor",
+		"/help":         "/help/#test-anchor",
+		"/help/":        "/help/#test-anchor",
+		"wails://help/": "wails://help/#test-anchor",
+	}
+	for basePath, want := range tests {
+		s, err := New(Options{Assets: testDocs, BasePath: basePath})
+		assert.NoError(t, err)
+		link, err := s.LinkFor("test-anchor")
+		assert.NoError(t, err)
+		assert.Equal(t, want, link, basePath)
+	}
+
+	for _, basePath := range []string{"help", "help/docs", "://nope"} {
+		_, err := New(Options{Assets: testDocs, BasePath: basePath})
+		assert.Error(t, err, basePath)
+	}
+}
+
+func TestLinkFor_Served(t *testing.T) {
+	s, err := New(Options{Assets: testDocs, Theme: ThemeDark})
+	assert.NoError(t, err)
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	link, err := s.LinkFor("good-anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, url+"?theme=dark#good-anchor", link)
+}
+
+func TestLinkFor_RemoteSource(t *testing.T) {
+	server := newDocsServer(t)
+	s, err := New(Options{Source: server.URL + "/docs"})
+	assert.NoError(t, err)
+
+	link, err := s.LinkFor("anywhere")
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/docs/#anywhere", link)
+}
+
+func TestAnchorAliases(t *testing.T) {
+	s, mockCore, mockDisplay := setupService(t, Options{
+		Assets: testDocs,
+		AnchorAliases: map[string]string{
+			"old-anchor":  "good-anchor",
+			"#quickstart": "#getting-started",
+		},
+	})
+	logger := mockCore.App().Logger().(*MockLogger)
+
+	assert.NoError(t, s.ShowAt("old-anchor"))
+	assert.Equal(t, "/#good-anchor", mockDisplay.Options["URL"])
+	assert.Equal(t, "good-anchor", s.CurrentAnchor())
+	assert.True(t, logger.InfoCalled)
+
+	link, err := s.LinkFor("#quickstart")
+	assert.NoError(t, err)
+	assert.Equal(t, "/#getting-started", link)
+
+	_, err = s.LinkFor("missing-anchor")
+	assert.Error(t, err)
+}
+
+func TestSourceInfo(t *testing.T) {
+	dir := t.TempDir()
+	server := newDocsServer(t)
+	tests := []struct {
+		opts Options
+		want SourceInfo
+	}{
+		{Options{}, SourceInfo{Kind: SourceEmbedded, Readable: true}},
+		{Options{Assets: testDocs}, SourceInfo{Kind: SourceFS, Readable: true}},
+		{Options{Source: dir}, SourceInfo{Kind: SourceDir, Location: dir, Readable: true}},
+		{Options{Source: filepath.Join(dir, "missing")}, SourceInfo{Kind: SourceDir, Location: filepath.Join(dir, "missing")}},
+		{Options{Source: server.URL + "/docs"}, SourceInfo{Kind: SourceHTTP, Location: server.URL + "/docs", Readable: true}},
+		{Options{Sources: []string{dir, "mkdocs"}}, SourceInfo{Kind: SourceLayered, Location: dir + ", mkdocs", Readable: true}},
+	}
+	for _, tt := range tests {
+		s, err := New(tt.opts)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, s.SourceInfo())
+	}
+}
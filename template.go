@@ -0,0 +1,60 @@
+package help
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"io"
+	"text/template"
+)
+
+// SetTemplateData replaces the values substituted into documentation pages,
+// such as the application's version or the user's plan. See
+// `Options.TemplateData`. Pages shown afterwards use the new values; a help
+// window already open shows them once reloaded, for example with `Reload`.
+//
+// Example:
+//
+//	helpService.SetTemplateData(map[string]any{"Version": version, "Tier": "pro"})
+//	err := helpService.Reload()
+func (s *Service) SetTemplateData(data map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts.TemplateData = data
+}
+
+// templateData returns the values substituted into documentation pages.
+func (s *Service) templateData() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.opts.TemplateData
+}
+
+// expandTemplate executes page as a template with data, and returns the
+// result. HTML pages are executed as an `html/template`, so that values are
+// escaped for where they appear, and other pages as a `text/template`. The
+// page is returned unchanged if data is nil, if the page has no "{{"
+// directives, or if it is not a valid template or fails to execute, as a
+// page that shows template syntax in its text would; a value missing from
+// data is such a failure.
+func expandTemplate(name string, page []byte, data map[string]any) []byte {
+	if data == nil || !bytes.Contains(page, []byte("{{")) {
+		return page
+	}
+	var tmpl interface {
+		Execute(w io.Writer, data any) error
+	}
+	var err error
+	if isHTML(name) {
+		tmpl, err = htmltemplate.New(name).Option("missingkey=error").Parse(string(page))
+	} else {
+		tmpl, err = template.New(name).Option("missingkey=error").Parse(string(page))
+	}
+	if err != nil {
+		return page
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return page
+	}
+	return buf.Bytes()
+}
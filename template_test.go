@@ -0,0 +1,60 @@
+package help
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	data := map[string]any{"Version": "1.2.3", "Tier": "pro"}
+
+	out := expandTemplate("index.md", []byte("Version {{.Version}} ({{.Tier}})"), data)
+	assert.Equal(t, "Version 1.2.3 (pro)", string(out))
+
+	for _, page := range []string{
+		"No directives here.",
+		"Broken {{.Version",
+		"Unknown {{template \"missing\"}}",
+		"Missing {{.Plan}}",
+	} {
+		assert.Equal(t, page, string(expandTemplate("index.md", []byte(page), data)))
+	}
+
+	// Values are escaped in HTML pages, and only there.
+	data["Tier"] = "<b>pro</b>"
+	out = expandTemplate("index.html", []byte(`<p title="{{.Tier}}">{{.Tier}}</p>`), data)
+	assert.Equal(t, `<p title="&lt;b&gt;pro&lt;/b&gt;">&lt;b&gt;pro&lt;/b&gt;</p>`, string(out))
+	out = expandTemplate("index.md", []byte("{{.Tier}}"), data)
+	assert.Equal(t, "<b>pro</b>", string(out))
+	assert.Equal(t, "<p>{{.Plan}}</p>", string(expandTemplate("index.html", []byte("<p>{{.Plan}}</p>"), data)))
+	assert.Equal(t, "{{.Version}}", string(expandTemplate("index.md", []byte("{{.Version}}"), nil)))
+}
+
+func TestTemplateData(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.md":   {Data: []byte("# Welcome\n\nYou are running {{.Version}}.\n")},
+		"index.html": {Data: []byte("<html><body>Plan: {{.Tier}}</body></html>")},
+	}
+	s, _, _ := setupService(t, Options{
+		Assets:       docs,
+		TemplateData: map[string]any{"Version": "1.2.3", "Tier": "free"},
+	})
+
+	page, err := s.RenderPage("index.md")
+	assert.NoError(t, err)
+	assert.Contains(t, page, "You are running 1.2.3.")
+
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+	assert.Equal(t, "<html><body>Plan: free</body></html>", get(t, url))
+
+	s.SetTemplateData(map[string]any{"Tier": "pro"})
+	assert.Equal(t, "<html><body>Plan: pro</body></html>", get(t, url))
+
+	data, err := s.ReadPage("index.md")
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "{{.Version}}")
+}
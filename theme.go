@@ -0,0 +1,92 @@
+package help
+
+import (
+	"context"
+	"fmt"
+)
+
+// Themes accepted by `Options.Theme` and `SetTheme`.
+const (
+	// ThemeLight shows the documentation in its light color scheme.
+	ThemeLight = "light"
+	// ThemeDark shows the documentation in its dark color scheme.
+	ThemeDark = "dark"
+	// ThemeAuto follows the appearance of the operating system.
+	ThemeAuto = "auto"
+)
+
+// SetTheme switches the color scheme of the help content to theme, one of
+// `ThemeLight`, `ThemeDark`, or `ThemeAuto`, or "" to leave the choice to the
+// documentation itself. The theme is passed to the content as a `theme` query
+// parameter on the help window URL, and pages served by `Serve` get the
+// matching mkdocs material color scheme; see `colorScheme`. An open help
+// window, whether opened through a `Display` service or by the `wails3`
+// fallback, is pointed at the same section with the new theme.
+//
+// Example:
+//
+//	err := helpService.SetTheme(help.ThemeDark)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (s *Service) SetTheme(theme string) error {
+	if err := checkTheme(theme); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.opts.Theme = theme
+	window, displayOpen := s.window, s.displayOpen
+	s.mu.Unlock()
+
+	url := s.pageURL(s.CurrentAnchor())
+	switch {
+	case s.display != nil && !s.inBrowser():
+		if displayOpen {
+			return s.openURL(context.Background(), url)
+		}
+	case window != nil:
+		window.SetURL(url)
+	}
+	return nil
+}
+
+// checkTheme returns an error if theme is not a supported theme.
+func checkTheme(theme string) error {
+	switch theme {
+	case "", ThemeLight, ThemeDark, ThemeAuto:
+		return nil
+	}
+	return fmt.Errorf("help: invalid theme %q", theme)
+}
+
+// theme returns the theme to show the content in. `ThemeAuto` is resolved
+// to light or dark from the operating system appearance when the `wails3`
+// application is running, and passed through to the content otherwise.
+func (s *Service) theme() string {
+	s.mu.Lock()
+	theme := s.opts.Theme
+	s.mu.Unlock()
+
+	if theme == ThemeAuto {
+		if app := s.wailsApp(); app != nil && app.Env != nil {
+			if app.Env.IsDarkMode() {
+				return ThemeDark
+			}
+			return ThemeLight
+		}
+	}
+	return theme
+}
+
+// colorScheme returns the mkdocs material color scheme of theme: "default"
+// for `ThemeLight`, "slate" for `ThemeDark`, and "" for any other theme,
+// which is left to the documentation.
+func colorScheme(theme string) string {
+	switch theme {
+	case ThemeLight:
+		return "default"
+	case ThemeDark:
+		return "slate"
+	}
+	return ""
+}
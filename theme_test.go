@@ -0,0 +1,100 @@
+package help
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTheme(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, Theme: ThemeDark})
+
+	err := s.Show()
+	assert.NoError(t, err)
+	assert.Equal(t, "/?theme=dark", mockDisplay.Options["URL"])
+
+	err = s.ShowAt("test-anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, "/?theme=dark#test-anchor", mockDisplay.Options["URL"])
+}
+
+func TestTheme_Auto(t *testing.T) {
+	// Without a running wails3 application the OS appearance is unknown, so
+	// the choice is passed through to the content.
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs, Theme: ThemeAuto})
+
+	err := s.ShowAt("test-anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, "/?theme=auto#test-anchor", mockDisplay.Options["URL"])
+}
+
+func TestNew_InvalidTheme(t *testing.T) {
+	_, err := New(Options{Theme: "purple"})
+	assert.EqualError(t, err, `help: invalid theme "purple"`)
+}
+
+func TestSetTheme(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	err := s.SetTheme(ThemeLight)
+	assert.NoError(t, err)
+	err = s.ShowAt("test-anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, "/?theme=light#test-anchor", mockDisplay.Options["URL"])
+
+	err = s.SetTheme("")
+	assert.NoError(t, err)
+	err = s.ShowAt("test-anchor")
+	assert.NoError(t, err)
+	assert.Equal(t, "/#test-anchor", mockDisplay.Options["URL"])
+}
+
+func TestSetTheme_Invalid(t *testing.T) {
+	s, _, _ := setupService(t, Options{Assets: testDocs, Theme: ThemeDark})
+
+	err := s.SetTheme("purple")
+	assert.Error(t, err)
+	assert.Equal(t, ThemeDark, s.theme())
+}
+
+func TestSetTheme_Display(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+	assert.NoError(t, s.ShowAt("test-anchor"))
+
+	mockDisplay.OpenCalled = false
+	assert.NoError(t, s.SetTheme(ThemeDark))
+	assert.True(t, mockDisplay.OpenCalled)
+	assert.Equal(t, "/?theme=dark#test-anchor", mockDisplay.Options["URL"])
+}
+
+func TestSetTheme_Fallback(t *testing.T) {
+	s, err := New(Options{Assets: testDocs})
+	assert.NoError(t, err)
+	windows := &fakeWindows{}
+	s.windowing = windows
+	assert.NoError(t, s.ShowAt("test-anchor"))
+
+	assert.NoError(t, s.SetTheme(ThemeLight))
+	url, _, _ := windows.windows()[0].state()
+	assert.Equal(t, "/?theme=light#test-anchor", url)
+}
+
+func TestServe_ColorScheme(t *testing.T) {
+	docs := fstest.MapFS{
+		"index.html": {Data: []byte(`<html><body class="page">Home</body></html>`)},
+		"dark.html":  {Data: []byte(`<html><body data-md-color-scheme="default">Dark</body></html>`)},
+	}
+	s, _, _ := setupService(t, Options{Assets: docs, Theme: ThemeDark})
+	url, err := s.Serve("")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.stopServing() })
+
+	assert.Contains(t, get(t, url+"index.html"), `<body data-md-color-scheme="slate" class="page">`)
+	assert.Contains(t, get(t, url+"dark.html"), `<body data-md-color-scheme="slate">`)
+	// The theme on the URL wins.
+	assert.Contains(t, get(t, url+"index.html?theme=light"), `<body data-md-color-scheme="default" class="page">`)
+
+	assert.NoError(t, s.SetTheme(""))
+	assert.Contains(t, get(t, url+"index.html"), `<body class="page">`)
+}
@@ -0,0 +1,73 @@
+package help
+
+import "context"
+
+// maxTOCLevel is the deepest heading level included in the table of contents.
+const maxTOCLevel = 3
+
+// TOCEntry is a single heading in the table of contents returned by
+// `TableOfContents`.
+type TOCEntry struct {
+	// Title is the text of the heading.
+	Title string `json:"title"`
+	// Anchor is the anchor of the heading, suitable for `ShowAt`.
+	Anchor string `json:"anchor"`
+	// Level is the heading level, from 1 to 3.
+	Level int `json:"level"`
+	// Path is the path of the documentation file containing the heading.
+	Path string `json:"path"`
+	// Children holds the deeper headings that follow this one, up to the
+	// next heading of the same or a higher level.
+	Children []TOCEntry `json:"children,omitempty"`
+}
+
+// TableOfContents returns the structure of the documentation as a tree of
+// headings, from level 1 to 3. Files with a `weight` or `order` in their
+// front matter come first, lowest first, and the rest follow in
+// alphabetical order by path; see `PageMeta`. Headings are listed in
+// document order, with each heading nested under the nearest preceding
+// heading of a higher level in the same file.
+//
+// Example:
+//
+//	toc, err := helpService.TableOfContents()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, entry := range toc {
+//		fmt.Println(entry.Title)
+//	}
+func (s *Service) TableOfContents() ([]TOCEntry, error) {
+	index, err := s.contentIndex(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return append([]TOCEntry(nil), index.toc...), nil
+}
+
+// buildTOC nests the headings of a single file into a tree.
+func buildTOC(p string, sections []section) []TOCEntry {
+	var entries []TOCEntry
+	for _, sec := range sections {
+		if sec.level < 1 || sec.level > maxTOCLevel {
+			continue
+		}
+		entries = insertTOC(entries, TOCEntry{
+			Title:  sec.title,
+			Anchor: sec.anchor,
+			Level:  sec.level,
+			Path:   p,
+		})
+	}
+	return entries
+}
+
+// insertTOC appends entry to the deepest last entry of entries that has a
+// lower level than entry, or to entries itself if there is none.
+func insertTOC(entries []TOCEntry, entry TOCEntry) []TOCEntry {
+	if n := len(entries); n > 0 && entries[n-1].Level < entry.Level {
+		entries[n-1].Children = insertTOC(entries[n-1].Children, entry)
+		return entries
+	}
+	return append(entries, entry)
+}
@@ -0,0 +1,44 @@
+package help
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableOfContents(t *testing.T) {
+	docs := fstest.MapFS{
+		"b.md":   {Data: []byte("# Beta\n\n## Setup\n\n### Linux\n\n#### Too Deep\n\n### macOS\n\n## Usage\n")},
+		"a.md":   {Data: []byte("## Orphan\n\n# Alpha\n")},
+		"c.html": {Data: []byte(`<h1 id="gamma">Gamma</h1><h2 id="gamma-intro">Intro</h2>`)},
+	}
+	s, err := New(Options{Assets: docs})
+	assert.NoError(t, err)
+
+	toc, err := s.TableOfContents()
+	assert.NoError(t, err)
+	assert.Equal(t, []TOCEntry{
+		{Title: "Orphan", Anchor: "orphan", Level: 2, Path: "a.md"},
+		{Title: "Alpha", Anchor: "alpha", Level: 1, Path: "a.md"},
+		{Title: "Beta", Anchor: "beta", Level: 1, Path: "b.md", Children: []TOCEntry{
+			{Title: "Setup", Anchor: "setup", Level: 2, Path: "b.md", Children: []TOCEntry{
+				{Title: "Linux", Anchor: "linux", Level: 3, Path: "b.md"},
+				{Title: "macOS", Anchor: "macos", Level: 3, Path: "b.md"},
+			}},
+			{Title: "Usage", Anchor: "usage", Level: 2, Path: "b.md"},
+		}},
+		{Title: "Gamma", Anchor: "gamma", Level: 1, Path: "c.html", Children: []TOCEntry{
+			{Title: "Intro", Anchor: "gamma-intro", Level: 2, Path: "c.html"},
+		}},
+	}, toc)
+}
+
+func TestTableOfContents_Empty(t *testing.T) {
+	s, err := New(Options{Assets: fstest.MapFS{}})
+	assert.NoError(t, err)
+
+	toc, err := s.TableOfContents()
+	assert.NoError(t, err)
+	assert.Empty(t, toc)
+}
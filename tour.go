@@ -0,0 +1,128 @@
+package help
+
+import (
+	"fmt"
+	"time"
+)
+
+// tour is a guided sequence of sections started by `Tour`.
+type tour struct {
+	anchors []string
+	pos     int
+	// stop is closed when the tour ends early.
+	stop chan struct{}
+}
+
+// Tour starts a guided tour through anchors, such as for onboarding new
+// users: the help window is opened at the first anchor, and then moves on
+// to the next one every delay. If delay is zero, the tour does not advance
+// by itself, and the host's UI steps through it with `NextStep` and
+// `PrevStep`. Every anchor is checked before the tour starts, and an error
+// is returned if any of them does not exist. The tour stops when the help
+// window is closed, or when another tour is started.
+//
+// Example:
+//
+//	err := helpService.Tour([]string{"welcome", "projects", "sharing"}, 8*time.Second)
+//	if err != nil {
+//		log.Println(err)
+//	}
+func (s *Service) Tour(anchors []string, delay time.Duration) error {
+	if len(anchors) == 0 {
+		return fmt.Errorf("help: tour has no steps")
+	}
+	steps := make([]string, len(anchors))
+	for i, anchor := range anchors {
+		steps[i] = s.resolveAnchor(anchor)
+		if _, fragment, _ := splitTarget(steps[i]); fragment == "" {
+			return fmt.Errorf("help: anchor must not be empty")
+		}
+		if err := s.checkAnchor(steps[i]); err != nil {
+			return err
+		}
+	}
+
+	s.stopTour()
+	if err := s.ShowAt(steps[0]); err != nil {
+		return err
+	}
+	t := &tour{anchors: steps, stop: make(chan struct{})}
+	s.mu.Lock()
+	s.tour = t
+	s.mu.Unlock()
+	if delay > 0 {
+		go s.runTour(t, delay)
+	}
+	return nil
+}
+
+// NextStep moves the tour started by `Tour` on to its next section. It
+// returns an error if no tour is in progress or the tour is at its last
+// step.
+func (s *Service) NextStep() error {
+	return s.tourStep(nil, 1)
+}
+
+// PrevStep moves the tour started by `Tour` back to its previous section. It
+// returns an error if no tour is in progress or the tour is at its first
+// step.
+func (s *Service) PrevStep() error {
+	return s.tourStep(nil, -1)
+}
+
+// tourStep moves delta steps through the tour t, or through the current tour
+// if t is nil, and opens the help window there.
+func (s *Service) tourStep(t *tour, delta int) error {
+	s.mu.Lock()
+	if t == nil {
+		t = s.tour
+	}
+	if t == nil || s.tour != t {
+		s.mu.Unlock()
+		return fmt.Errorf("help: no tour in progress")
+	}
+	pos := t.pos + delta
+	if pos < 0 || pos >= len(t.anchors) {
+		s.mu.Unlock()
+		if delta < 0 {
+			return fmt.Errorf("help: tour is at its first step")
+		}
+		return fmt.Errorf("help: tour is at its last step")
+	}
+	anchor := t.anchors[pos]
+	s.mu.Unlock()
+
+	if err := s.ShowAt(anchor); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	t.pos = pos
+	s.mu.Unlock()
+	return nil
+}
+
+// runTour advances the tour t every delay until it reaches its last step or
+// is stopped.
+func (s *Service) runTour(t *tour, delay time.Duration) {
+	for range t.anchors[1:] {
+		select {
+		case <-t.stop:
+			return
+		case <-time.After(delay):
+		}
+		if err := s.tourStep(t, 1); err != nil {
+			s.logger().Error(fmt.Sprintf("help: tour: %v", err))
+			return
+		}
+	}
+}
+
+// stopTour ends the tour in progress, if any.
+func (s *Service) stopTour() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tour != nil {
+		close(s.tour.stop)
+		s.tour = nil
+	}
+}
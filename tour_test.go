@@ -0,0 +1,57 @@
+package help
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTour(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs})
+	display := &countingDisplay{}
+	s.Init(mockCore, display)
+
+	err := s.Tour([]string{"test-anchor", "#good-anchor", "any-anchor"}, 10*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(display.opened()) == 3 && s.CurrentAnchor() == "any-anchor"
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"/#test-anchor", "/#good-anchor", "/#any-anchor"}, display.opened())
+}
+
+func TestTour_Steps(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	assert.EqualError(t, s.NextStep(), "help: no tour in progress")
+	assert.NoError(t, s.Tour([]string{"test-anchor", "good-anchor"}, 0))
+	assert.Equal(t, "/#test-anchor", mockDisplay.Options["URL"])
+
+	assert.EqualError(t, s.PrevStep(), "help: tour is at its first step")
+	assert.NoError(t, s.NextStep())
+	assert.Equal(t, "/#good-anchor", mockDisplay.Options["URL"])
+	assert.EqualError(t, s.NextStep(), "help: tour is at its last step")
+	assert.NoError(t, s.PrevStep())
+	assert.Equal(t, "/#test-anchor", mockDisplay.Options["URL"])
+}
+
+func TestTour_InvalidAnchor(t *testing.T) {
+	s, _, mockDisplay := setupService(t, Options{Assets: testDocs})
+
+	assert.Error(t, s.Tour(nil, 0))
+	assert.Error(t, s.Tour([]string{"test-anchor", "missing"}, 0))
+	assert.Error(t, s.Tour([]string{"test-anchor", " "}, 0))
+	assert.False(t, mockDisplay.OpenCalled)
+}
+
+func TestTour_StopsOnClose(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Assets: testDocs})
+	display := &countingDisplay{}
+	s.Init(mockCore, display)
+
+	assert.NoError(t, s.Tour([]string{"test-anchor", "good-anchor"}, 20*time.Millisecond))
+	assert.NoError(t, s.Close())
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []string{"/#test-anchor"}, display.opened())
+	assert.EqualError(t, s.NextStep(), "help: no tour in progress")
+}
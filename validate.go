@@ -0,0 +1,91 @@
+package help
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrNoDocumentation is wrapped by the error `Validate` returns when the
+// documentation source contains no markdown or HTML files.
+var ErrNoDocumentation = errors.New("no documentation files")
+
+// errFoundDoc stops the walk in `validate` at the first documentation file.
+var errFoundDoc = errors.New("found documentation file")
+
+// Validate checks that the documentation source is usable: that a `Source`
+// directory exists, and that the documentation contains at least one
+// markdown or HTML file. If the embedded "mkdocs" documentation is empty,
+// usually because the docs were not built into `public/` before the
+// application was compiled, the error says so. Errors for a source without
+// documentation wrap `ErrNoDocumentation`. Remote sources cannot be listed,
+// so Validate only checks that the server is reachable, switching to
+// `Options.FallbackAssets` if it is not; see `CheckSource`. The fallback
+// assets are then checked in its place. `ServiceStartup` calls Validate, so
+// that a misconfigured source is reported when the application starts
+// rather than when help is first shown.
+//
+// Example:
+//
+//	if err := helpService.Validate(); err != nil {
+//		log.Fatal(err)
+//	}
+func (s *Service) Validate() error {
+	return s.validate(context.Background())
+}
+
+// validate is `Validate`, with ctx bounding the check of a remote source.
+func (s *Service) validate(ctx context.Context) error {
+	s.mu.Lock()
+	opts := s.opts
+	s.mu.Unlock()
+
+	if _, ok := s.rootAssets().(*httpFS); ok || s.isOffline() {
+		err := s.CheckSource(ctx)
+		if !s.isOffline() {
+			return err
+		}
+		// The remote source is unreachable and the fallback assets are in
+		// use, so check them as any other source.
+		opts = Options{Assets: opts.FallbackAssets}
+	}
+	assets := s.currentAssets()
+
+	name := sourceName(opts)
+	if opts.Assets == nil && len(opts.Sources) == 0 && opts.Source != "mkdocs" {
+		info, err := os.Stat(opts.Source)
+		if err != nil {
+			return fmt.Errorf("help: invalid source %q: %w", opts.Source, err)
+		}
+		if !info.IsDir() && !isZipSource(opts.Source) {
+			return fmt.Errorf("help: invalid source %q: not a directory", opts.Source)
+		}
+	}
+
+	err := walkDocPaths(assets, s.warnWalk, func(string) error {
+		return errFoundDoc
+	})
+	switch {
+	case errors.Is(err, errFoundDoc):
+		return nil
+	case err != nil:
+		return fmt.Errorf("help: source %q: %w", name, err)
+	}
+	if opts.Assets == nil && len(opts.Sources) == 0 && opts.Source == "mkdocs" && !hasDefaultAssets() {
+		return fmt.Errorf("help: embedded documentation assets are missing; build the docs into public/ before compiling: %w", ErrNoDocumentation)
+	}
+	return fmt.Errorf("help: source %q contains %w", name, ErrNoDocumentation)
+}
+
+// sourceName describes the documentation source of opts for error messages.
+func sourceName(opts Options) string {
+	switch {
+	case opts.Assets != nil:
+		return "assets"
+	case len(opts.Sources) > 0:
+		return strings.Join(opts.Sources, ", ")
+	}
+	return opts.Source
+}
@@ -0,0 +1,80 @@
+package help
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "guide"), 0o755)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "guide", "setup.md"), []byte("# Setup\n"), 0o644)
+	assert.NoError(t, err)
+
+	for _, opts := range []Options{{Assets: testDocs}, {Source: dir}, {Sources: []string{dir, "mkdocs"}}} {
+		s, err := New(opts)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Validate())
+	}
+}
+
+func TestValidate_NoDocumentation(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "logo.png"), []byte("png"), 0o644)
+	assert.NoError(t, err)
+
+	s, err := New(Options{Source: dir})
+	assert.NoError(t, err)
+	err = s.Validate()
+	assert.EqualError(t, err, fmt.Sprintf("help: source %q contains no documentation files", dir))
+
+	s, err = New(Options{Assets: fstest.MapFS{}})
+	assert.NoError(t, err)
+	err = s.Validate()
+	assert.EqualError(t, err, `help: source "assets" contains no documentation files`)
+}
+
+func TestValidate_MissingSource(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	s, err := New(Options{Source: dir})
+	assert.NoError(t, err)
+
+	err = s.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid source")
+}
+
+func TestValidate_RemoteSource(t *testing.T) {
+	server := newDocsServer(t)
+	s, err := New(Options{Source: server.URL + "/docs"})
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate())
+
+	server.Close()
+	assert.Error(t, s.Validate())
+}
+
+func TestValidate_EmptyAssets(t *testing.T) {
+	s, err := New(Options{Assets: fstest.MapFS{}})
+	assert.NoError(t, err)
+
+	err = s.Validate()
+	assert.ErrorIs(t, err, ErrNoDocumentation)
+}
+
+func TestValidate_EmptyEmbedded(t *testing.T) {
+	// The embedded public/ directory holds only a .gitkeep until the docs
+	// are built into it.
+	s, err := New(Options{})
+	assert.NoError(t, err)
+
+	err = s.Validate()
+	assert.ErrorIs(t, err, ErrNoDocumentation)
+	assert.Contains(t, err.Error(), "embedded documentation assets are missing")
+}
@@ -0,0 +1,92 @@
+package help
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+)
+
+// walkDocPaths calls fn with the path of every markdown and HTML file in
+// fsys, in lexical path order. Unlike `fs.WalkDir`, it follows symbolic
+// links to directories, as found in monorepos that link a docs directory
+// into place. A link back to a directory it is inside of would never end,
+// so it is skipped, as is a link whose target is missing, and warn is
+// called with a message saying why. warn may be nil.
+func walkDocPaths(fsys fs.FS, warn func(msg string), fn func(p string) error) error {
+	root, err := fs.Stat(fsys, ".")
+	if err != nil {
+		return err
+	}
+	if warn == nil {
+		warn = func(string) {}
+	}
+	return walkDocDir(fsys, ".", []fs.FileInfo{root}, warn, fn)
+}
+
+// walkDocDir walks the directory dir of fsys for `walkDocPaths`. ancestors
+// holds dir and the directories above it, to detect symbolic link cycles.
+func walkDocDir(fsys fs.FS, dir string, ancestors []fs.FileInfo, warn func(string), fn func(p string) error) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		p := path.Join(dir, entry.Name())
+		if entry.Type()&fs.ModeSymlink == 0 {
+			if !entry.IsDir() {
+				if isDocFile(p) {
+					if err := fn(p); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			if err := walkDocDir(fsys, p, append(ancestors, info), warn, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		info, err := fs.Stat(fsys, p)
+		if err != nil {
+			warn(fmt.Sprintf("help: skipping broken symbolic link %q: %v", p, err))
+			continue
+		}
+		if !info.IsDir() {
+			if isDocFile(p) {
+				if err := fn(p); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if inside(info, ancestors) {
+			warn(fmt.Sprintf("help: skipping symbolic link %q: it links to a directory that contains it", p))
+			continue
+		}
+		if err := walkDocDir(fsys, p, append(ancestors, info), warn, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inside reports whether the directory dir is one of ancestors.
+func inside(dir fs.FileInfo, ancestors []fs.FileInfo) bool {
+	for _, ancestor := range ancestors {
+		if os.SameFile(dir, ancestor) {
+			return true
+		}
+	}
+	return false
+}
+
+// warnWalk logs a warning about the documentation found while walking it.
+func (s *Service) warnWalk(msg string) {
+	s.logger().Error(msg)
+}
@@ -0,0 +1,48 @@
+package help
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// symlinkedDocs returns a documentation directory with a symbolic link to a
+// directory elsewhere, a link back to itself, and a broken link.
+func symlinkedDocs(t *testing.T) string {
+	base := t.TempDir()
+	docs := filepath.Join(base, "docs")
+	shared := filepath.Join(base, "shared")
+	assert.NoError(t, os.MkdirAll(filepath.Join(docs, "guide"), 0o755))
+	assert.NoError(t, os.Mkdir(shared, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(docs, "index.md"), []byte("# Welcome\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(shared, "faq.md"), []byte("# FAQ\n"), 0o644))
+	for link, target := range map[string]string{
+		filepath.Join(docs, "shared"):        shared,
+		filepath.Join(docs, "guide", "loop"): docs,
+		filepath.Join(docs, "broken.md"):     filepath.Join(base, "missing.md"),
+	} {
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("symbolic links not supported: %v", err)
+		}
+	}
+	return docs
+}
+
+func TestListPages_Symlinks(t *testing.T) {
+	s, mockCore, _ := setupService(t, Options{Source: symlinkedDocs(t)})
+
+	pages, err := s.ListPages()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"index.md", "shared/faq.md"}, pages)
+	assert.True(t, mockCore.App().Logger().(*MockLogger).ErrorCalled)
+
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"faq", "welcome"}, anchors)
+
+	toc, err := s.TableOfContents()
+	assert.NoError(t, err)
+	assert.Len(t, toc, 2)
+}
@@ -0,0 +1,94 @@
+package help
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch watches a local directory source for changes and reloads the help
+// window, as `Reload` does, whenever a file in it is created, written,
+// removed, or renamed, so that edits to the documentation show up without
+// restarting the application. The index used by `Search` and
+// `TableOfContents` is rebuilt too. It blocks until ctx is cancelled, and
+// returns nil without watching anything for embedded, `Assets`, `Sources`,
+// zip archive, or remote sources. Watch keeps watching the directory it
+// started with if the source is later changed with `SetSource`. `Shutdown`
+// stops it too.
+//
+// Example:
+//
+//	go func() {
+//		if err := helpService.Watch(ctx); err != nil {
+//			log.Println("help: watch:", err)
+//		}
+//	}()
+func (s *Service) Watch(ctx context.Context) error {
+	s.mu.Lock()
+	source, assets, layered := s.opts.Source, s.opts.Assets, len(s.opts.Sources) > 0
+	s.mu.Unlock()
+	if assets != nil || layered || source == "mkdocs" || isRemoteSource(source) || isZipSource(source) {
+		return nil
+	}
+	ctx, stop := s.trackWatch(ctx)
+	defer stop()
+	return watchDir(ctx, source, func(string) {
+		s.invalidateIndex()
+		if err := s.Reload(); err != nil {
+			s.logger().Error(fmt.Sprintf("help: reload: %v", err))
+		}
+	})
+}
+
+// watchDir calls onChange with the path of each file that is created,
+// written, removed, or renamed under dir, until ctx is cancelled.
+// Directories created while watching are watched too.
+func watchDir(ctx context.Context, dir string, onChange func(name string)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = watcher.Add(event.Name)
+				}
+			}
+			onChange(event.Name)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package help
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch_NotLocal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	archive := writeZip(t, map[string]string{"index.md": "# Home\n"})
+	for _, opts := range []Options{{}, {Assets: testDocs}, {Source: "https://docs.example.com"}, {Source: archive}} {
+		s, err := New(opts)
+		assert.NoError(t, err)
+		// Watch returns at once rather than blocking until ctx is done.
+		assert.NoError(t, s.Watch(ctx))
+	}
+}
+
+func TestWatch_StopsOnCancel(t *testing.T) {
+	s, err := New(Options{Source: t.TempDir()})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Watch(ctx) }()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch did not stop after cancel")
+	}
+}
+
+func TestWatch_MissingSource(t *testing.T) {
+	s, err := New(Options{Source: filepath.Join(t.TempDir(), "missing")})
+	assert.NoError(t, err)
+
+	err = s.Watch(context.Background())
+	assert.Error(t, err)
+}
+
+func TestWatchDir(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	changed := make(chan string, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- watchDir(ctx, dir, func(name string) { changed <- name })
+	}()
+
+	file := filepath.Join(dir, "index.md")
+	assert.Eventually(t, func() bool {
+		_ = os.WriteFile(file, []byte("# Changed\n"), 0o644)
+		select {
+		case name := <-changed:
+			return name == file
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,73 @@
+package help
+
+import (
+	"unsafe"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+	"github.com/wailsapp/wails/v3/pkg/events"
+)
+
+// helpWindow is a window of the `wails3` fallback: the methods of
+// `*application.WebviewWindow` that the service uses, so that tests can
+// stand in for the windows of a running application.
+type helpWindow interface {
+	Name() string
+	SetURL(url string) application.Window
+	Show() application.Window
+	Hide() application.Window
+	Focus()
+	Close()
+	Reload()
+	Size() (width, height int)
+	Bounds() application.Rect
+	SetPosition(x, y int)
+	Center()
+	NativeWindow() unsafe.Pointer
+	OnWindowEvent(eventType events.WindowEventType, callback func(event *application.WindowEvent)) func()
+}
+
+// windowFactory creates and finds the windows of the `wails3` fallback.
+type windowFactory interface {
+	// NewWithOptions creates a window with options.
+	NewWithOptions(options application.WebviewWindowOptions) helpWindow
+	// GetByName returns the window named name, and whether there is one.
+	GetByName(name string) (helpWindow, bool)
+	// Current returns the application's active window, or nil.
+	Current() helpWindow
+}
+
+// wailsWindows is the windowFactory of a `wails3` application.
+type wailsWindows struct {
+	manager *application.WindowManager
+}
+
+func (w wailsWindows) NewWithOptions(options application.WebviewWindowOptions) helpWindow {
+	return w.manager.NewWithOptions(options)
+}
+
+func (w wailsWindows) GetByName(name string) (helpWindow, bool) {
+	window, ok := w.manager.GetByName(name)
+	if !ok {
+		return nil, false
+	}
+	hw, ok := window.(helpWindow)
+	return hw, ok
+}
+
+func (w wailsWindows) Current() helpWindow {
+	hw, _ := w.manager.Current().(helpWindow)
+	return hw
+}
+
+// windowFactory returns the windows of the `wails3` fallback: those set in
+// windowing, or those of the application returned by `wailsApp`. It returns
+// nil if there is neither.
+func (s *Service) windowFactory() windowFactory {
+	if s.windowing != nil {
+		return s.windowing
+	}
+	if app := s.wailsApp(); app != nil {
+		return wailsWindows{manager: app.Window}
+	}
+	return nil
+}
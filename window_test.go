@@ -0,0 +1,185 @@
+package help
+
+import (
+	"slices"
+	"sync"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+	"github.com/wailsapp/wails/v3/pkg/events"
+)
+
+// fakeWindows is a windowFactory that creates fakeWindows in place of the
+// windows of a running application.
+type fakeWindows struct {
+	mu      sync.Mutex
+	created []*fakeWindow
+	named   map[string]*fakeWindow
+	current *fakeWindow
+}
+
+func (f *fakeWindows) NewWithOptions(options application.WebviewWindowOptions) helpWindow {
+	window := &fakeWindow{
+		options:   options,
+		url:       options.URL,
+		x:         options.X,
+		y:         options.Y,
+		width:     options.Width,
+		height:    options.Height,
+		shown:     true,
+		listeners: make(map[events.WindowEventType][]func(*application.WindowEvent)),
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created = append(f.created, window)
+	return window
+}
+
+func (f *fakeWindows) GetByName(name string) (helpWindow, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	window, ok := f.named[name]
+	if !ok {
+		return nil, false
+	}
+	return window, true
+}
+
+func (f *fakeWindows) Current() helpWindow {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.current == nil {
+		return nil
+	}
+	return f.current
+}
+
+// windows returns the windows created so far.
+func (f *fakeWindows) windows() []*fakeWindow {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*fakeWindow(nil), f.created...)
+}
+
+// add adds window, which the factory did not create, under its name.
+func (f *fakeWindows) add(window *fakeWindow) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.named == nil {
+		f.named = make(map[string]*fakeWindow)
+	}
+	f.named[window.Name()] = window
+}
+
+// fakeWindow records what the service does to a window. Closing it emits
+// `WindowClosing`, as closing a real window does.
+type fakeWindow struct {
+	mu        sync.Mutex
+	options   application.WebviewWindowOptions
+	url       string
+	x, y      int
+	width     int
+	height    int
+	shown     bool
+	focused   bool
+	closed    bool
+	reloads   int
+	native    unsafe.Pointer
+	listeners map[events.WindowEventType][]func(*application.WindowEvent)
+}
+
+func (w *fakeWindow) Name() string { return w.options.Name }
+
+func (w *fakeWindow) SetURL(url string) application.Window {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.url = url
+	return nil
+}
+
+func (w *fakeWindow) Show() application.Window {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.shown = true
+	return nil
+}
+
+func (w *fakeWindow) Hide() application.Window {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.shown = false
+	return nil
+}
+
+func (w *fakeWindow) Focus() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.focused = true
+}
+
+func (w *fakeWindow) Close() {
+	w.mu.Lock()
+	w.closed, w.shown = true, false
+	w.mu.Unlock()
+	w.emit(events.Common.WindowClosing)
+}
+
+func (w *fakeWindow) Reload() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.reloads++
+}
+
+func (w *fakeWindow) Size() (int, int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.width, w.height
+}
+
+func (w *fakeWindow) Bounds() application.Rect {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return application.Rect{X: w.x, Y: w.y, Width: w.width, Height: w.height}
+}
+
+func (w *fakeWindow) SetPosition(x, y int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.x, w.y = x, y
+}
+
+func (w *fakeWindow) Center() {}
+
+func (w *fakeWindow) NativeWindow() unsafe.Pointer { return w.native }
+
+func (w *fakeWindow) OnWindowEvent(eventType events.WindowEventType, callback func(*application.WindowEvent)) func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners[eventType] = append(w.listeners[eventType], callback)
+	return func() {}
+}
+
+// emit calls the listeners of eventType, as the application does when the
+// event occurs.
+func (w *fakeWindow) emit(eventType events.WindowEventType) {
+	w.mu.Lock()
+	listeners := slices.Clone(w.listeners[eventType])
+	w.mu.Unlock()
+	for _, listener := range listeners {
+		listener(application.NewWindowEvent())
+	}
+}
+
+// state returns the window's URL and whether it is shown and closed.
+func (w *fakeWindow) state() (url string, shown, closed bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.url, w.shown, w.closed
+}
+
+// position returns the window's position.
+func (w *fakeWindow) position() (x, y int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.x, w.y
+}
@@ -0,0 +1,106 @@
+package help
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// isZipSource reports whether source is the path of a zip archive, judged by
+// its extension.
+func isZipSource(source string) bool {
+	return !isRemoteSource(source) && strings.EqualFold(filepath.Ext(source), ".zip")
+}
+
+// zipFS is an fs.FS that reads documentation from a zip archive on disk.
+// The archive is kept open between reads; `Close` releases it, and the next
+// read opens it again. Files are read into memory when they are opened, so
+// they stay readable after the archive is closed.
+type zipFS struct {
+	path string
+
+	mu     sync.Mutex
+	reader *zip.ReadCloser
+}
+
+// newZipFS opens the zip archive at path.
+func newZipFS(path string) (*zipFS, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("help: invalid source %q: %w", path, err)
+	}
+	return &zipFS{path: path, reader: reader}, nil
+}
+
+// Open opens the named file in the archive, opening the archive again if it
+// has been closed. The contents of a regular file are read in full.
+func (z *zipFS) Open(name string) (fs.File, error) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if z.reader == nil {
+		reader, err := zip.OpenReader(z.path)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		z.reader = reader
+	}
+	f, err := z.reader.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		// Directories are listed from the archive's index, in memory.
+		return f, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	return &zipFile{Reader: bytes.NewReader(data), info: info}, nil
+}
+
+// zipFile is a regular file of a zipFS, held in memory.
+type zipFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *zipFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *zipFile) Close() error               { return nil }
+
+// Close releases the archive's file handle.
+func (z *zipFS) Close() error {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if z.reader == nil {
+		return nil
+	}
+	err := z.reader.Close()
+	z.reader = nil
+	return err
+}
+
+// closeAssets releases the file handles held by a documentation filesystem:
+// a zip archive, or the zip archives among the layers of an overlay.
+func closeAssets(fsys fs.FS) error {
+	switch fsys := fsys.(type) {
+	case *overlayFS:
+		var first error
+		for _, layer := range fsys.layers {
+			if err := closeAssets(layer); err != nil && first == nil {
+				first = err
+			}
+		}
+		return first
+	case *zipFS:
+		return fsys.Close()
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package help
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeZip writes a zip archive of files to a temporary directory and
+// returns its path.
+func writeZip(t *testing.T, files map[string]string) string {
+	p := filepath.Join(t.TempDir(), "docs.zip")
+	f, err := os.Create(p)
+	assert.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		entry, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	assert.NoError(t, f.Close())
+	return p
+}
+
+func TestZipSource(t *testing.T) {
+	archive := writeZip(t, map[string]string{
+		"index.md":       "# Welcome\n\n## Getting Started\n",
+		"guide/setup.md": "# Setup\n\nRun the installer.\n",
+	})
+	s, _, mockDisplay := setupService(t, Options{Source: archive})
+	z, ok := s.assets.(*zipFS)
+	assert.True(t, ok)
+
+	assert.NoError(t, s.Validate())
+	anchors, err := s.Anchors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"getting-started", "setup", "welcome"}, anchors)
+	results, err := s.Search("installer")
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.NoError(t, s.ShowAt("setup"))
+	assert.Equal(t, "/guide/setup.html#setup", mockDisplay.Options["URL"])
+
+	info := s.SourceInfo()
+	assert.Equal(t, SourceZip, info.Kind)
+	assert.True(t, info.Readable)
+
+	// Close releases the archive, which is opened again when needed.
+	assert.NoError(t, s.Close())
+	assert.Nil(t, z.reader)
+	data, err := s.ReadPage("guide/setup.md")
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "installer")
+	assert.NotNil(t, z.reader)
+
+	// Switching source closes the previous archive.
+	assert.NoError(t, s.SetSource(Options{Assets: testDocs}))
+	assert.Nil(t, z.reader)
+}
+
+func TestZipSource_OpenFileOutlivesArchive(t *testing.T) {
+	archive := writeZip(t, map[string]string{"index.md": "# Welcome\n\nRead me after the switch.\n"})
+	s, err := New(Options{Source: archive})
+	assert.NoError(t, err)
+	z := s.assets.(*zipFS)
+
+	f, err := s.OpenPage("index.md")
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.NoError(t, s.SetSource(Options{Assets: testDocs}))
+	assert.Nil(t, z.reader)
+
+	data, err := io.ReadAll(f)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Welcome\n\nRead me after the switch.\n", string(data))
+}
+
+func TestZipSource_Invalid(t *testing.T) {
+	_, err := New(Options{Source: filepath.Join(t.TempDir(), "missing.zip")})
+	assert.Error(t, err)
+
+	bad := filepath.Join(t.TempDir(), "bad.zip")
+	assert.NoError(t, os.WriteFile(bad, []byte("not a zip"), 0o644))
+	_, err = New(Options{Source: bad})
+	assert.Error(t, err)
+}